@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+const answersUsage = `usage: aoc answers [-file path] <subcommand> [arguments]
+
+subcommands:
+  list                          list the recorded answers (the default)
+  verify <day> <part> [answer]  record an accepted answer, running the solution if none is given
+  wrong <day> <part> <answer>   record a rejected answer
+  forget <day> <part>           mark the answer as unknown again
+`
+
+// answersCommand implements "aoc answers", which reads and updates the
+// answers manifest for each problem's current input.
+func answersCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("answers", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "", "answers manifest (default: the nearest "+eulerlib.AnswersFile+" above the solutions)")
+	timeout := fs.Duration("timeout", eulerlib.DefaultTimeout, "time limit when verify runs a solution (0 for none)")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, answersUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	args = fs.Args()
+	sub := "list"
+	if len(args) > 0 {
+		sub, args = args[0], args[1:]
+	}
+
+	var err error
+	switch sub {
+	case "list":
+		err = listAnswers(stdout, *file)
+	case "verify":
+		err = updateAnswer(stdout, *file, args, 2, 3, func(m *eulerlib.Manifest, info eulerlib.ProblemInfo, hash string, answer eulerlib.Answer) (string, error) {
+			if len(args) == 2 {
+				result := eulerlib.RunInfo(context.Background(), info, eulerlib.RunOptions{Timeout: *timeout})
+				if result.Err != nil {
+					return "", fmt.Errorf("running %s: %w", info, result.Err)
+				}
+				answer = result.Answer
+			}
+			return fmt.Sprintf("recorded %s as the verified answer for %s", answer, info), m.Verify(info.Year, info.Day, info.Part, hash, answer)
+		})
+	case "wrong":
+		err = updateAnswer(stdout, *file, args, 3, 3, func(m *eulerlib.Manifest, info eulerlib.ProblemInfo, hash string, answer eulerlib.Answer) (string, error) {
+			return fmt.Sprintf("recorded %s as a wrong answer for %s", answer, info), m.AddWrong(info.Year, info.Day, info.Part, hash, answer)
+		})
+	case "forget":
+		err = updateAnswer(stdout, *file, args, 2, 2, func(m *eulerlib.Manifest, info eulerlib.ProblemInfo, hash string, answer eulerlib.Answer) (string, error) {
+			m.Forget(info.Year, info.Day, info.Part, hash)
+			return fmt.Sprintf("marked the answer for %s as unknown", info), nil
+		})
+	default:
+		fmt.Fprintf(stderr, "aoc answers: unknown subcommand %q\n\n", sub)
+		fs.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc answers:", err)
+		return 1
+	}
+	return 0
+}
+
+// manifestPath returns the manifest to use for info: the -file flag, else
+// the nearest existing answers.json, else a new one beside the day folders.
+func manifestPath(file string, info eulerlib.ProblemInfo) string {
+	if file != "" {
+		return file
+	}
+	if path := eulerlib.FindManifest(info.Dir); path != "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(info.Dir), eulerlib.AnswersFile)
+}
+
+// updateAnswer parses "<day> <part> [answer]" from args, which must have
+// between minArgs and maxArgs entries, applies update to the manifest record
+// for the problem's current full input and saves the manifest.
+func updateAnswer(stdout io.Writer, file string, args []string, minArgs, maxArgs int,
+	update func(m *eulerlib.Manifest, info eulerlib.ProblemInfo, hash string, answer eulerlib.Answer) (string, error)) error {
+	if len(args) < minArgs || len(args) > maxArgs {
+		return errors.New("expected <day> <part> [answer]")
+	}
+	info, err := parseDayPart(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	var answer eulerlib.Answer
+	if len(args) == 3 {
+		answer = eulerlib.ParseAnswer(args[2])
+	}
+	lines, err := info.LoadInput(false)
+	if err != nil {
+		return err
+	}
+	manifest, err := eulerlib.LoadManifest(manifestPath(file, info))
+	if err != nil {
+		return err
+	}
+	message, err := update(manifest, info, eulerlib.HashInput(lines), answer)
+	if err != nil {
+		return err
+	}
+	if err := manifest.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, message)
+	return nil
+}
+
+// parseDayPart returns the registered problem for a day and part given as
+// command line arguments.
+func parseDayPart(dayArg, partArg string) (eulerlib.ProblemInfo, error) {
+	day, err := strconv.Atoi(dayArg)
+	if err != nil {
+		return eulerlib.ProblemInfo{}, fmt.Errorf("invalid day %q", dayArg)
+	}
+	part, err := strconv.Atoi(partArg)
+	if err != nil {
+		return eulerlib.ProblemInfo{}, fmt.Errorf("invalid part %q", partArg)
+	}
+	info, ok := eulerlib.DefaultRegistry().Get(eulerlib.DefaultYear, day, part)
+	if !ok {
+		return eulerlib.ProblemInfo{}, fmt.Errorf("no solution for day %d, part %d", day, part)
+	}
+	return info, nil
+}
+
+// listAnswers prints the manifest entry for each registered problem's
+// current input, or "none" where there is no entry.
+func listAnswers(w io.Writer, file string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tSTATUS\tANSWER\tWRONG\tINPUT")
+	for _, info := range eulerlib.DefaultRegistry().All() {
+		lines, err := info.LoadInput(false)
+		if err != nil {
+			fmt.Fprintf(tw, "%d\t%d\tno input\t\t\t\n", info.Day, info.Part)
+			continue
+		}
+		hash := eulerlib.HashInput(lines)
+		manifest, err := eulerlib.LoadManifest(manifestPath(file, info))
+		if err != nil {
+			return err
+		}
+		status, answer, wrong := "none", "", ""
+		if r, ok := manifest.Lookup(info.Year, info.Day, info.Part, hash); ok {
+			status, answer = string(r.Status), tableAnswer(r.Answer)
+			wrongs := make([]string, len(r.Wrong))
+			for i, a := range r.Wrong {
+				wrongs[i] = tableAnswer(a)
+			}
+			wrong = strings.Join(wrongs, ",")
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\n", info.Day, info.Part, status, answer, wrong, hash[:12])
+	}
+	return tw.Flush()
+}
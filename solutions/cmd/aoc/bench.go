@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// benchCommand implements "aoc bench", solving each selected problem
+// repeatedly and printing timing and memory statistics.
+func benchCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	all := fs.Bool("all", false, "benchmark every problem")
+	short := fs.Bool("short", false, "benchmark the short (sample) input")
+	runs := fs.Int("n", 10, "number of runs per problem")
+	asJSON := fs.Bool("json", false, "write the results as JSON")
+	inputs := fs.String("inputs", "", "directory of dayX-Y/ input folders searched before "+eulerlib.InputDirEnv+" and the package directories")
+	timeout := fs.Duration("timeout", eulerlib.DefaultTimeout, "per-run time limit (0 for none)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: aoc bench [-n runs] [-short] [-json] [-inputs dir] [-timeout d] (-all | <day> [part])")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *runs < 1 {
+		fmt.Fprintln(stderr, "aoc bench: -n must be at least 1")
+		return 2
+	}
+	eulerlib.SetInputDir(*inputs)
+
+	selected, err := parseSelection(*all, fs.Args())
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc bench:", err)
+		fs.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	opts := eulerlib.BenchOptions{Runs: *runs, Short: *short, Timeout: *timeout}
+	results := make([]eulerlib.BenchResult, len(selected))
+	restore := divertStdout()
+	for i, info := range selected {
+		results[i] = eulerlib.Bench(ctx, info, opts)
+	}
+	restore()
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(eulerlib.NewBenchReport(results)); err != nil {
+			fmt.Fprintln(stderr, "aoc bench:", err)
+			return 1
+		}
+	} else {
+		writeBenchResults(stdout, results)
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			return 1
+		}
+	}
+	return 0
+}
+
+// divertStdout points os.Stdout at os.Stderr until the returned function is
+// called, so that anything a solution prints while it is benchmarked cannot
+// end up in the results, which may be JSON for another program to read.
+func divertStdout() (restore func()) {
+	saved := os.Stdout
+	os.Stdout = os.Stderr
+	return func() { os.Stdout = saved }
+}
+
+// writeBenchResults prints one row of statistics per problem.
+func writeBenchResults(w io.Writer, results []eulerlib.BenchResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tNAME\tRUNS\tMIN\tMEDIAN\tP95\tALLOCS/RUN\tBYTES/RUN\tPEAK HEAP")
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%d\t%s\t\t\t\t\t\n", r.Day, r.Part, r.Name, r.Runs, r.Err)
+			continue
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%d\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			r.Day, r.Part, r.Name, r.Runs,
+			formatDuration(r.Min), formatDuration(r.Median), formatDuration(r.P95),
+			r.AllocsPerRun, formatBytes(r.BytesPerRun), formatBytes(r.PeakHeap))
+	}
+	tw.Flush()
+}
+
+// formatBytes prints a byte count with a binary unit suffix.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
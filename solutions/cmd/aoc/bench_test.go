@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// BenchmarkSolutions benchmarks the short answer of every registered
+// solution, e.g. go test -bench 'Solutions/day7-' ./cmd/aoc
+func BenchmarkSolutions(b *testing.B) {
+	eulerlib.BenchmarkRegistry(b, eulerlib.DefaultRegistry(), true)
+}
@@ -0,0 +1,29 @@
+package main
+
+// Each solution package registers itself with eulerlib.Register from its init
+// function, so importing it here is enough for the runner to find it.
+import (
+	_ "github.com/nfitbh72/aoc2025/solutions/day1-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day1-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day10-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day10-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day11-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day11-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day12-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day2-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day2-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day3-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day3-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day4-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day4-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day5-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day5-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day6-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day6-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day7-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day7-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day8-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day8-2"
+	_ "github.com/nfitbh72/aoc2025/solutions/day9-1"
+	_ "github.com/nfitbh72/aoc2025/solutions/day9-2"
+)
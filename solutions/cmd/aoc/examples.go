@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// examplesCommand implements "aoc examples", writing the examples of a
+// puzzle description into a solution's folder and recording the sample
+// answer in the answers manifest.
+func examplesCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("examples", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	page := fs.String("page", "", "saved puzzle page (default: the cached "+eulerlib.PuzzleFile+", fetched if need be)")
+	file := fs.String("file", "", "answers manifest (default: the nearest "+eulerlib.AnswersFile+" above the solutions)")
+	dir := fs.String("dir", "", "folder to write to (default: the solution's own)")
+	overwrite := fs.Bool("overwrite", false, "replace existing example files")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: aoc examples [-page file] [-dir dir] [-file path] [-overwrite] <day> <part>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 2 {
+		fmt.Fprintln(stderr, "aoc examples: expected <day> <part>")
+		fs.Usage()
+		return 2
+	}
+	info, err := parseDayPart(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc examples:", err)
+		return 2
+	}
+	if *dir != "" {
+		info.Dir = *dir
+	}
+	if err := writeExamples(stdout, info, *page, *file, *overwrite); err != nil {
+		fmt.Fprintln(stderr, "aoc examples:", err)
+		return 1
+	}
+	return 0
+}
+
+// writeExamples extracts info's examples from a puzzle page and writes them
+// into its folder.
+func writeExamples(stdout io.Writer, info eulerlib.ProblemInfo, page, file string, overwrite bool) error {
+	if page == "" {
+		var err error
+		if page, err = puzzlePage(info); err != nil {
+			return err
+		}
+	}
+	b, err := os.ReadFile(page)
+	if err != nil {
+		return err
+	}
+	examples := eulerlib.PartExamples(eulerlib.ParsePuzzleExamples(string(b)), info.Part)
+	if len(examples) == 0 {
+		return fmt.Errorf("%s: no examples for part %d", page, info.Part)
+	}
+	written, err := eulerlib.WriteExamples(info.Dir, examples, overwrite)
+	if err != nil {
+		return err
+	}
+	for _, path := range written {
+		fmt.Fprintln(stdout, "wrote", path)
+	}
+
+	answer := examples[0].Answer
+	if answer.IsEmpty() {
+		fmt.Fprintf(stdout, "no answer found for the sample input of %s\n", info)
+		return nil
+	}
+	manifest, err := eulerlib.LoadManifest(manifestPath(file, info))
+	if err != nil {
+		return err
+	}
+	if err := manifest.RecordExample(info.Year, info.Day, info.Part, eulerlib.HashInput(examples[0].Input), answer); err != nil {
+		return err
+	}
+	if err := manifest.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "recorded %s as the sample answer for %s\n", answer, info)
+	return nil
+}
+
+// puzzlePage returns the cached puzzle page for info, fetching it when there
+// is a session token to fetch it with.
+func puzzlePage(info eulerlib.ProblemInfo) (string, error) {
+	session, err := eulerlib.LoadSession()
+	f := eulerlib.NewFetcher(session)
+	path := f.CachePath(info.Year, info.Day, eulerlib.PuzzleFile)
+	if _, statErr := os.Stat(path); statErr == nil || !errors.Is(statErr, fs.ErrNotExist) {
+		return path, statErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s is not cached and cannot be fetched (%w); use -page", path, err)
+	}
+	return f.Puzzle(context.Background(), info.Year, info.Day)
+}
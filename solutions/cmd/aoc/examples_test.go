@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+func TestExamplesCommand(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(t.TempDir(), eulerlib.AnswersFile)
+	page := filepath.Join("..", "..", "lib", "testdata", "puzzle-day1.html")
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := dispatch(append([]string{"examples", "-page", page, "-dir", dir, "-file", manifest}, args...), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, errOut := run("1", "2")
+	eulerlib.CheckTest(t, "aoc.examples", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	if !strings.Contains(out, "recorded 6 as the sample answer for 2025 day 1 part 2") {
+		t.Errorf("unexpected output %q %q", out, errOut)
+	}
+	lines, err := eulerlib.ReadLines(filepath.Join(dir, eulerlib.DefaultShortInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := eulerlib.LoadManifest(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, ok := m.Lookup(eulerlib.DefaultYear, 1, 2, eulerlib.HashInput(lines))
+	if !ok || r.Status != eulerlib.AnswerExample || r.Answer.String() != "6" {
+		t.Errorf("expected the sample answer in the manifest, got %+v", m.Records)
+	}
+
+	code, _, errOut = run("1", "2")
+	eulerlib.CheckTest(t, "aoc.examples", eulerlib.TTest{Name: "existing files", Expect: 1}, code)
+	if !strings.Contains(errOut, "already exists") {
+		t.Errorf("unexpected error %q", errOut)
+	}
+	code, _, _ = run("1")
+	eulerlib.CheckTest(t, "aoc.examples", eulerlib.TTest{Name: "usage", Expect: 2}, code)
+}
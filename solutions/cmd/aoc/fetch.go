@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// fetchCommand implements "aoc fetch", downloading the inputs and examples of
+// the given days into the shared inputs cache.
+func fetchCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	year := fs.Int("year", eulerlib.DefaultYear, "puzzle year")
+	cache := fs.String("cache", eulerlib.InputCacheDir(), "inputs cache `dir`")
+	url := fs.String("url", eulerlib.DefaultFetchURL, "site to download from")
+	interval := fs.Duration("interval", eulerlib.DefaultFetchInterval, "least time between requests")
+	example := fs.Bool("example", true, "also fetch the puzzle description and its example")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: aoc fetch [-year year] [-cache dir] [-example=false] <day>...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(stderr, "aoc fetch: expected at least one day")
+		fs.Usage()
+		return 2
+	}
+	var days []int
+	for _, arg := range fs.Args() {
+		day, err := strconv.Atoi(arg)
+		if err != nil || day < 1 || day > 25 {
+			fmt.Fprintf(stderr, "aoc fetch: invalid day %q: must be between 1 and 25\n", arg)
+			return 2
+		}
+		days = append(days, day)
+	}
+	session, err := eulerlib.LoadSession()
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc fetch:", err)
+		return 1
+	}
+
+	f := eulerlib.NewFetcher(session)
+	f.CacheDir = *cache
+	f.BaseURL = *url
+	f.Interval = *interval
+	ctx := context.Background()
+	code := 0
+	for _, day := range days {
+		fetches := []func(context.Context, int, int) (string, error){f.Input}
+		if *example {
+			fetches = append(fetches, f.Example)
+		}
+		for _, fetch := range fetches {
+			path, err := fetch(ctx, *year, day)
+			if err != nil {
+				fmt.Fprintf(stderr, "aoc fetch: day %d: %v\n", day, err)
+				code = 1
+				break
+			}
+			fmt.Fprintln(stdout, path)
+		}
+	}
+	return code
+}
+
+// inputFetcher returns a Fetcher for "aoc run -fetch", or an error if there
+// is no session token.
+func inputFetcher() (*eulerlib.Fetcher, error) {
+	session, err := eulerlib.LoadSession()
+	if err != nil {
+		return nil, err
+	}
+	return eulerlib.NewFetcher(session), nil
+}
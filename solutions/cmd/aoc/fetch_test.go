@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+func TestFetchCommand(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/2025/day/2/input":
+			w.Write([]byte("11-22,95-115\n"))
+		case "/2025/day/2":
+			w.Write([]byte("<p>For example:</p><pre><code>11-22\n</code></pre>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	t.Setenv(eulerlib.SessionEnv, "secret")
+	cache := t.TempDir()
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := dispatch(append([]string{"fetch", "-cache", cache, "-url", server.URL, "-interval", "0"}, args...), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, _ := run("2")
+	eulerlib.CheckTest(t, "aoc.fetch", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	for _, name := range []string{"input.txt", "input-test.txt"} {
+		if !strings.Contains(out, filepath.Join(cache, "2025", "day2", name)) {
+			t.Errorf("expected %s to be listed, got %q", name, out)
+		}
+	}
+	code, _, _ = run("2")
+	eulerlib.CheckTest(t, "aoc.fetch", eulerlib.TTest{Name: "cached exit code", Expect: 0}, code)
+	eulerlib.CheckTest(t, "aoc.fetch", eulerlib.TTest{Name: "requests", Expect: 2}, requests)
+
+	code, _, errOut := run("3")
+	eulerlib.CheckTest(t, "aoc.fetch", eulerlib.TTest{Name: "locked day", Expect: 1}, code)
+	if !strings.Contains(errOut, "unlocked") {
+		t.Errorf("unexpected error %q", errOut)
+	}
+	code, _, _ = run("26")
+	eulerlib.CheckTest(t, "aoc.fetch", eulerlib.TTest{Name: "invalid day", Expect: 2}, code)
+}
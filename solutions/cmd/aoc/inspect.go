@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// inspectShapes, inspectChars and inspectFields limit how many line shapes,
+// characters and integer fields "aoc inspect" lists.
+const (
+	inspectShapes = 8
+	inspectChars  = 12
+	inspectFields = 8
+)
+
+// inspectCommand implements "aoc inspect", printing statistics about an input
+// file to show its shape before a solution is written.
+func inspectCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	short := fs.Bool("short", false, "inspect the short (sample) input")
+	file := fs.String("file", "", "inspect this `file` rather than a solution's input")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: aoc inspect [-short] <day> <part>")
+		fmt.Fprintln(stderr, "       aoc inspect -file path")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var lines []string
+	var name string
+	var err error
+	switch {
+	case *file != "" && fs.NArg() == 0:
+		name = *file
+		lines, err = eulerlib.ReadLines(*file)
+	case *file == "" && fs.NArg() == 2:
+		var info eulerlib.ProblemInfo
+		if info, err = parseDayPart(fs.Arg(0), fs.Arg(1)); err != nil {
+			fmt.Fprintln(stderr, "aoc inspect:", err)
+			return 2
+		}
+		name = info.String()
+		lines, err = info.LoadInput(*short)
+	default:
+		fmt.Fprintln(stderr, "aoc inspect: expected <day> <part> or -file")
+		fs.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc inspect:", err)
+		return 1
+	}
+	printInputStats(stdout, name, eulerlib.Inspect(lines))
+	return 0
+}
+
+// printInputStats writes stats for the input called name.
+func printInputStats(w io.Writer, name string, stats eulerlib.InputStats) {
+	fmt.Fprintf(w, "%s: %d lines (%d blank), up to %d characters wide\n", name, stats.Lines, stats.BlankLines, stats.Width)
+	if !stats.Grid.IsZero() {
+		fmt.Fprintf(w, "grid: %s\n", stats.Grid)
+	}
+
+	fmt.Fprintf(w, "shapes: %d\n", len(stats.Shapes))
+	for i, shape := range stats.Shapes {
+		if i == inspectShapes {
+			fmt.Fprintf(w, "  ... and %d more\n", len(stats.Shapes)-i)
+			break
+		}
+		fmt.Fprintf(w, "  %6d  %s  (first on line %d)\n", shape.Count, abbreviate(shape.Shape, 60), shape.Line)
+	}
+
+	var chars []string
+	for i, c := range stats.Chars {
+		if i == inspectChars {
+			chars = append(chars, fmt.Sprintf("... and %d more", len(stats.Chars)-i))
+			break
+		}
+		chars = append(chars, fmt.Sprintf("%s %d", strconv.QuoteRune(c.Char), c.Count))
+	}
+	fmt.Fprintf(w, "chars: %s\n", strings.Join(chars, ", "))
+
+	if stats.Ints.Count > 0 {
+		fmt.Fprintf(w, "integers: %d, from %d to %d\n", stats.Ints.Count, stats.Ints.Min, stats.Ints.Max)
+	}
+	if len(stats.Fields) > 1 {
+		var fields []string
+		for i, f := range stats.Fields {
+			if i == inspectFields {
+				fields = append(fields, fmt.Sprintf("... and %d more", len(stats.Fields)-i))
+				break
+			}
+			fields = append(fields, fmt.Sprintf("#%d %d..%d", i+1, f.Min, f.Max))
+		}
+		fmt.Fprintf(w, "fields of %s: %s\n", abbreviate(stats.Shapes[0].Shape, 30), strings.Join(fields, ", "))
+	}
+
+	if len(stats.Sections) > 1 {
+		fmt.Fprintf(w, "sections: %d\n", len(stats.Sections))
+		for _, section := range stats.Sections {
+			fmt.Fprintf(w, "  line %d: %s, %s", section.Line, plural(section.Lines, "line"), plural(section.Shapes, "shape"))
+			if !section.Grid.IsZero() && section.Lines > 1 {
+				fmt.Fprintf(w, ", grid %s", section.Grid)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	for _, warning := range stats.Warnings {
+		fmt.Fprintln(w, "warning:", warning)
+	}
+}
+
+// abbreviate shortens s to at most n characters.
+func abbreviate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+func TestInspectCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "input.txt")
+	input := "0:\n###\n#..\n\n1:\n.#.\n\n4x4: 0 2\n12x5: 1 0\n"
+	if err := os.WriteFile(file, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"inspect", "-file", file}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.inspect", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	out := stdout.String()
+	for _, want := range []string{
+		file + ": 9 lines (2 blank), up to 9 characters wide",
+		"       2  NxN: N N  (first on line 8)",
+		"integers: 10, from 0 to 12",
+		"sections: 3",
+		"  line 1: 3 lines, 3 shapes",
+		"  line 8: 2 lines, 1 shape",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the output:\n%s", want, out)
+		}
+	}
+
+	code = dispatch([]string{"inspect", "1"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.inspect", eulerlib.TTest{Name: "usage", Expect: 2}, code)
+	code = dispatch([]string{"inspect", "-file", filepath.Join(t.TempDir(), "missing.txt")}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.inspect", eulerlib.TTest{Name: "missing file", Expect: 1}, code)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// listCommand implements "aoc list", printing the registered problems and
+// their metadata, optionally filtered by tag.
+func listCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	tag := fs.String("tag", "", "only list problems with this tag")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "YEAR\tDAY\tPART\tTITLE\tTAGS\tDIR")
+	for _, info := range eulerlib.DefaultRegistry().All() {
+		if *tag != "" && !info.HasTag(*tag) {
+			continue
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t%s\n",
+			info.Year, info.Day, info.Part, info.Title, strings.Join(info.Tags, ","), info.Dir)
+	}
+	tw.Flush()
+	return 0
+}
@@ -0,0 +1,68 @@
+// Command aoc runs the Advent of Code solutions in-process and reports their
+// answers.
+//
+// Usage:
+//
+//	aoc run <day> [part]
+//	aoc run -all
+//	aoc bench [-n runs] [-json] (-all | <day> [part])
+//	aoc list [-tag tag]
+//	aoc answers [list | verify <day> <part> [answer] | wrong <day> <part> <answer> | forget <day> <part>]
+//	aoc new [-clone] <day> <part>
+//	aoc fetch <day>...
+//	aoc submit <day> <part> [answer]
+//	aoc examples <day> <part>
+//	aoc inspect [-short] <day> <part>
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: aoc <command> [arguments]
+
+commands:
+  run       run one day/part, or every problem with -all
+  bench     time and measure the memory use of problems over repeated runs
+  list      list the registered problems
+  answers   list or record known answers in the answers manifest
+  new       create the package for a new day and part
+  fetch     download puzzle inputs and examples into the inputs cache
+  submit    submit an answer, guarding against ones already known to be wrong
+  examples  write the examples in a puzzle description into a solution folder
+  inspect   show the shape of an input: lines, characters, grid size and integer ranges
+`
+
+// commands maps each sub-command name to its implementation. Each command
+// receives its own arguments and returns the process exit code.
+var commands = map[string]func(args []string, stdout, stderr io.Writer) int{
+	"run":      runCommand,
+	"bench":    benchCommand,
+	"list":     listCommand,
+	"answers":  answersCommand,
+	"new":      newCommand,
+	"fetch":    fetchCommand,
+	"submit":   submitCommand,
+	"examples": examplesCommand,
+	"inspect":  inspectCommand,
+}
+
+func main() {
+	os.Exit(dispatch(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// dispatch looks up the sub-command named by the first argument and runs it.
+func dispatch(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "aoc: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	return command(args[1:], stdout, stderr)
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+func TestDispatchUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"nope"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.dispatch", eulerlib.TTest{Name: "exit code", Expect: 2}, code)
+	if !strings.Contains(stderr.String(), `unknown command "nope"`) {
+		t.Errorf("expected unknown command message, got %q", stderr.String())
+	}
+}
+
+func TestParseSelection(t *testing.T) {
+	tests := []eulerlib.TTest{
+		{Name: "all", Input: []string{}, Expect: len(eulerlib.DefaultRegistry().All()), KeyValues: map[string]any{"all": true}},
+		{Name: "day", Input: []string{"7"}, Expect: 2},
+		{Name: "day and part", Input: []string{"7", "2"}, Expect: 1},
+		{Name: "single part day", Input: []string{"12"}, Expect: 1},
+	}
+	for _, test := range tests {
+		all, _ := test.KeyValues["all"].(bool)
+		selected, err := parseSelection(all, test.Input.([]string))
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.Name, err)
+			continue
+		}
+		eulerlib.CheckTest(t, "aoc.parseSelection", test, len(selected))
+	}
+}
+
+func TestParseSelectionErrors(t *testing.T) {
+	tests := []eulerlib.TTest{
+		{Name: "nothing", Input: []string{}, Expect: "expected -all or <day> [part]"},
+		{Name: "bad day", Input: []string{"x"}, Expect: `invalid day "x"`},
+		{Name: "bad part", Input: []string{"1", "y"}, Expect: `invalid part "y"`},
+		{Name: "missing day", Input: []string{"30"}, Expect: "no solution for day 30"},
+		{Name: "missing part", Input: []string{"12", "2"}, Expect: "no solution for day 12, part 2"},
+	}
+	for _, test := range tests {
+		_, err := parseSelection(false, test.Input.([]string))
+		if err == nil {
+			t.Errorf("%s: expected an error", test.Name)
+			continue
+		}
+		eulerlib.CheckTest(t, "aoc.parseSelection", test, err.Error())
+	}
+}
+
+func TestRunCommandShort(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"run", "-short", "1"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.run", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	out := stdout.String()
+	for _, want := range []string{"Day 1, Part 1", "Day 1, Part 2", "2 passed, 0 failed, 0 unknown"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunCommandInputsOverride(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "day1-1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// a single left turn of 50 clicks lands on zero once
+	if err := os.WriteFile(filepath.Join(dir, "day1-1", "input-test.txt"), []byte("L50\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { eulerlib.SetInputDir("") })
+
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"run", "-short", "-inputs", dir, "1", "1"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.run", eulerlib.TTest{Name: "exit code", Expect: 1}, code)
+	if !strings.Contains(stdout.String(), "Day 1, Part 1  1 ") {
+		t.Errorf("expected the overridden input to produce 1, got:\n%s", stdout.String())
+	}
+}
+
+func TestRunCommandParallel(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"run", "-short", "-j", "2", "1"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.run", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	out := stdout.String()
+	//results are listed in registry order whichever finishes first
+	if i, j := strings.Index(out, "Day 1, Part 1"), strings.Index(out, "Day 1, Part 2"); i < 0 || j < i {
+		t.Errorf("expected part 1 before part 2, got:\n%s", out)
+	}
+	if !strings.Contains(out, "2 passed, 0 failed, 0 unknown") || !strings.Contains(out, "on 2 workers)") {
+		t.Errorf("expected a parallel summary, got:\n%s", out)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	code = dispatch([]string{"run", "-j", "2", "-top", "3", "1"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.run", eulerlib.TTest{Name: "profiling exit code", Expect: 2}, code)
+}
+
+func TestAnswersCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "answers.json")
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := dispatch(append([]string{"answers", "-file", file}, args...), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, _ := run("wrong", "1", "1", "998")
+	eulerlib.CheckTest(t, "aoc.answers", eulerlib.TTest{Name: "wrong", Expect: 0}, code)
+	if !strings.Contains(out, "recorded 998 as a wrong answer for 2025 day 1 part 1") {
+		t.Errorf("unexpected wrong output %q", out)
+	}
+	code, _, errOut := run("verify", "1", "1", "998")
+	eulerlib.CheckTest(t, "aoc.answers", eulerlib.TTest{Name: "verify wrong", Expect: 1}, code)
+	if !strings.Contains(errOut, "recorded as a wrong answer") {
+		t.Errorf("unexpected verify error %q", errOut)
+	}
+	code, _, _ = run("verify", "1", "1")
+	eulerlib.CheckTest(t, "aoc.answers", eulerlib.TTest{Name: "verify by running", Expect: 0}, code)
+
+	code, out, _ = run("list")
+	eulerlib.CheckTest(t, "aoc.answers", eulerlib.TTest{Name: "list", Expect: 0}, code)
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "1    1 ") && !(strings.Contains(line, "verified  999") && strings.Contains(line, "998")) {
+			t.Errorf("unexpected list row %q", line)
+		}
+		if strings.HasPrefix(line, "1    2 ") && !strings.Contains(line, "none") {
+			t.Errorf("unexpected list row %q", line)
+		}
+	}
+
+	code, _, _ = run("verify", "1")
+	eulerlib.CheckTest(t, "aoc.answers", eulerlib.TTest{Name: "missing part", Expect: 1}, code)
+	code, _, _ = run("bogus")
+	eulerlib.CheckTest(t, "aoc.answers", eulerlib.TTest{Name: "unknown subcommand", Expect: 2}, code)
+}
+
+func TestRunCommandProfiles(t *testing.T) {
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"run", "-short", "-cpuprofile", dir, "-memprofile", dir, "-trace", dir, "-top", "3", "1", "1"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.run", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	for _, name := range []string{"day1-1-short.cpu.pprof", "day1-1-short.mem.pprof", "day1-1-short.trace"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected profile %s: %v", name, err)
+		}
+	}
+	if !strings.Contains(stdout.String(), "Day 1, Part 1: ") || !strings.Contains(stdout.String(), "of CPU sampled") {
+		t.Errorf("expected a top summary, got:\n%s", stdout.String())
+	}
+	if stderr.Len() > 0 {
+		t.Errorf("unexpected errors: %s", stderr.String())
+	}
+}
+
+func TestRunCommandLogging(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "run.log")
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"run", "-short", "-log-format", "json", "-log-file", file, "8", "2"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.run", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"msg":"distances sorted","problem":"day8-2"`) {
+		t.Errorf("expected day 8's progress in the log file, got:\n%s", b)
+	}
+	if stderr.Len() > 0 {
+		t.Errorf("expected nothing on stderr, got:\n%s", stderr.String())
+	}
+
+	stdout.Reset()
+	code = dispatch([]string{"run", "-short", "-log", "off", "8", "2"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.run", eulerlib.TTest{Name: "silenced exit code", Expect: 0}, code)
+	if stderr.Len() > 0 {
+		t.Errorf("expected -log off to silence the progress output, got:\n%s", stderr.String())
+	}
+
+	code = dispatch([]string{"run", "-log", "loud", "8", "2"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.run", eulerlib.TTest{Name: "bad level exit code", Expect: 2}, code)
+}
+
+func TestProgressFlag(t *testing.T) {
+	tests := []eulerlib.TTest{
+		{Name: "bare", Input: "true", Expect: "auto"},
+		{Name: "tty", Input: "tty", Expect: "tty"},
+		{Name: "log", Input: "log", Expect: "log"},
+		{Name: "off", Input: "off", Expect: "off"},
+	}
+	for _, test := range tests {
+		var f progressFlag
+		if err := f.Set(test.Input.(string)); err != nil {
+			t.Fatalf("%s: %v", test.Name, err)
+		}
+		eulerlib.CheckTest(t, "aoc.progressFlag", test, f.String())
+	}
+	var f progressFlag
+	if err := f.Set("bars"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+
+	var stderr bytes.Buffer
+	if newProgress("off", &stderr, eulerlib.GetDebugger()) != nil {
+		t.Error("expected no progress when it is off")
+	}
+	if newProgress("auto", &stderr, eulerlib.GetDebugger()) == nil {
+		t.Error("expected progress log lines when stderr is not a terminal")
+	}
+}
+
+func TestRunCommandProgress(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"run", "-short", "-progress", "9", "2"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.run", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	if !strings.Contains(stdout.String(), "1 passed") {
+		t.Errorf("expected day 9 part 2 to pass, got:\n%s", stdout.String())
+	}
+}
+
+func TestBenchCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"bench", "-n", "3", "-short", "1", "1"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.bench", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	if !strings.Contains(stdout.String(), "MEDIAN") || !strings.Contains(stdout.String(), "Day 1, Part 1  3 ") {
+		t.Errorf("unexpected bench output:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	code = dispatch([]string{"bench", "-n", "2", "-short", "-json", "1"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.bench", eulerlib.TTest{Name: "json exit code", Expect: 0}, code)
+	var report eulerlib.BenchReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON %v:\n%s", err, stdout.String())
+	}
+	eulerlib.CheckTest(t, "aoc.bench", eulerlib.TTest{Name: "json results", Expect: 2}, len(report.Results))
+	eulerlib.CheckTest(t, "aoc.bench", eulerlib.TTest{Name: "json answer", Expect: "6"}, report.Results[1].Answer.String())
+
+	code = dispatch([]string{"bench", "-n", "0", "1"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.bench", eulerlib.TTest{Name: "bad runs", Expect: 2}, code)
+}
+
+func TestDivertStdout(t *testing.T) {
+	saved := os.Stdout
+	restore := divertStdout()
+	diverted := os.Stdout
+	restore()
+	if diverted != os.Stderr {
+		t.Error("expected os.Stdout to be diverted to os.Stderr")
+	}
+	if os.Stdout != saved {
+		t.Error("expected os.Stdout to be restored")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []eulerlib.TTest{
+		{Name: "bytes", Input: uint64(512), Expect: "512B"},
+		{Name: "kib", Input: uint64(1536), Expect: "1.5KiB"},
+		{Name: "mib", Input: uint64(3 << 20), Expect: "3.0MiB"},
+	}
+	for _, test := range tests {
+		eulerlib.CheckTest(t, "aoc.formatBytes", test, formatBytes(test.Input.(uint64)))
+	}
+}
+
+func TestListCommandTag(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"list", "-tag", "graph"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.list", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	out := stdout.String()
+	if !strings.Contains(out, "Reactor") || strings.Contains(out, "Secret Entrance") {
+		t.Errorf("expected only graph-tagged problems, got:\n%s", out)
+	}
+}
+
+func TestRegisteredSolutions(t *testing.T) {
+	all := eulerlib.DefaultRegistry().All()
+	eulerlib.CheckTest(t, "aoc.registry", eulerlib.TTest{Name: "count", Expect: 23}, len(all))
+	for _, info := range all {
+		if filepath.Base(info.Dir) != info.FolderName() {
+			t.Errorf("%s registered from %s", info, info.Dir)
+		}
+	}
+}
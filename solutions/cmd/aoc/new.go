@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// solutionsModule is the import path prefix of the day packages.
+const solutionsModule = "github.com/nfitbh72/aoc2025/solutions"
+
+// templateExt marks the files in the templates directory that are rendered
+// into a new day folder.
+const templateExt = ".tmpl"
+
+// daysFile is the file, relative to the solutions directory, that imports
+// every day package into the aoc command.
+var daysFile = filepath.Join("cmd", "aoc", "days.go")
+
+// scaffold is the data the templates are executed with.
+type scaffold struct {
+	Year    int
+	Day     int
+	Part    int
+	Package string
+	Folder  string
+	Name    string
+	Title   string
+	Tags    []string
+}
+
+// newScaffold returns the template data for a day and part.
+func newScaffold(day, part int, title string, tags []string) scaffold {
+	info := eulerlib.ProblemInfo{Year: eulerlib.DefaultYear, Day: day, Part: part}
+	return scaffold{
+		Year:    info.Year,
+		Day:     day,
+		Part:    part,
+		Package: packageName(day, part),
+		Folder:  info.FolderName(),
+		Name:    fmt.Sprintf("Day %d, Part %d", day, part),
+		Title:   title,
+		Tags:    tags,
+	}
+}
+
+// packageName returns the Go package name used for a day and part, such as
+// day3part1.
+func packageName(day, part int) string {
+	return fmt.Sprintf("day%dpart%d", day, part)
+}
+
+// newCommand implements "aoc new", creating the package for a new day and
+// part from the templates, or by cloning part 1 into part 2.
+func newCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dir := fs.String("dir", "", "solutions directory holding the dayX-Y folders (default: the one the solutions were built from)")
+	templates := fs.String("templates", "", "directory of *"+templateExt+" templates (default: templates in the solutions directory)")
+	title := fs.String("title", "", "puzzle title")
+	tags := fs.String("tags", "", "comma-separated tags")
+	clone := fs.Bool("clone", false, "create part 2 by copying part 1, resetting its name and answers")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: aoc new [-dir dir] [-templates dir] [-title title] [-tags a,b] [-clone] <day> <part>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	day, part, err := parseNewDayPart(fs.Args())
+	if err == nil && *clone && part != 2 {
+		err = errors.New("-clone creates part 2 from part 1")
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc new:", err)
+		fs.Usage()
+		return 2
+	}
+
+	root := *dir
+	if root == "" {
+		if root, err = solutionsDir(); err != nil {
+			fmt.Fprintln(stderr, "aoc new:", err)
+			return 1
+		}
+	}
+	if *templates == "" {
+		*templates = filepath.Join(root, "templates")
+	}
+	data := newScaffold(day, part, *title, splitTags(*tags))
+	target := filepath.Join(root, data.Folder)
+
+	var created []string
+	if *clone {
+		created, err = cloneDay(filepath.Join(root, newScaffold(day, 1, "", nil).Folder), target, data)
+	} else {
+		created, err = renderTemplates(*templates, target, data)
+	}
+	if err == nil {
+		err = addDayImport(filepath.Join(root, daysFile), solutionsModule+"/"+data.Folder)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc new:", err)
+		return 1
+	}
+	for _, file := range created {
+		fmt.Fprintln(stdout, "created", file)
+	}
+	fmt.Fprintf(stdout, "added %s to %s\n", data.Folder, daysFile)
+	return 0
+}
+
+// parseNewDayPart parses the day and part arguments of "aoc new".
+func parseNewDayPart(args []string) (day, part int, err error) {
+	if len(args) != 2 {
+		return 0, 0, errors.New("expected <day> <part>")
+	}
+	if day, err = strconv.Atoi(args[0]); err != nil || day < 1 || day > 25 {
+		return 0, 0, fmt.Errorf("invalid day %q: must be between 1 and 25", args[0])
+	}
+	if part, err = strconv.Atoi(args[1]); err != nil || part < 1 || part > 2 {
+		return 0, 0, fmt.Errorf("invalid part %q: must be 1 or 2", args[1])
+	}
+	return day, part, nil
+}
+
+// splitTags splits a comma-separated tag list, dropping empty entries.
+func splitTags(s string) []string {
+	var tags []string
+	for tag := range strings.SplitSeq(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// solutionsDir returns the directory holding the registered day folders.
+func solutionsDir() (string, error) {
+	all := eulerlib.DefaultRegistry().All()
+	if len(all) == 0 || all[0].Dir == "" {
+		return "", errors.New("cannot find the solutions directory; use -dir")
+	}
+	return filepath.Dir(all[0].Dir), nil
+}
+
+// renderTemplates executes every template in dir into target, which must not
+// exist yet, naming each file after its template without the extension. Go
+// files are gofmt'd. It returns the files created.
+func renderTemplates(dir, target string, data scaffold) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+templateExt))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no *%s templates in %s", templateExt, dir)
+	}
+	files := map[string][]byte{}
+	for _, path := range paths {
+		tmpl, err := template.ParseFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(filepath.Base(path), templateExt)
+		b := buf.Bytes()
+		if filepath.Ext(name) == ".go" {
+			if b, err = format.Source(b); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+		files[name] = b
+	}
+	return writeDay(target, files)
+}
+
+// cloneDay copies the Go sources and input files of a part 1 folder into a
+// new part 2 folder, renaming the package and resetting the problem's name,
+// registration and answers to those of data.
+func cloneDay(source, target string, data scaffold) ([]string, error) {
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, entry := range entries {
+		name := entry.Name()
+		isGo := filepath.Ext(name) == ".go"
+		if entry.IsDir() || !(isGo || strings.HasPrefix(name, "input")) {
+			//examples and notes belong to part 1's answers
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(source, name))
+		if err != nil {
+			return nil, err
+		}
+		if isGo {
+			if b, err = cloneSource(b, data); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		files[name] = b
+	}
+	return writeDay(target, files)
+}
+
+// cloneSource rewrites a part 1 source file for data's part: the package
+// name, the Part given to eulerlib.Register, the name returned by
+// GetProblemName, and empty GetAnswer and GetShortAnswer results.
+func cloneSource(src []byte, data scaffold) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	from := packageName(data.Day, 1)
+	file.Name.Name = strings.Replace(file.Name.Name, from, data.Package, 1)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.KeyValueExpr:
+			if key, ok := n.Key.(*ast.Ident); ok && key.Name == "Part" {
+				if lit, ok := n.Value.(*ast.BasicLit); ok && lit.Kind == token.INT {
+					lit.Value = strconv.Itoa(data.Part)
+				}
+			}
+		case *ast.FuncDecl:
+			if n.Recv == nil || n.Body == nil {
+				return true
+			}
+			switch n.Name.Name {
+			case "GetProblemName":
+				setReturnString(n, data.Name)
+			case "GetAnswer", "GetShortAnswer":
+				setReturnString(n, "")
+			}
+		}
+		return true
+	})
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// setReturnString replaces the string literals returned by fn with s.
+func setReturnString(fn *ast.FuncDecl, s string) {
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if ret, ok := n.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			if lit, ok := ret.Results[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				lit.Value = strconv.Quote(s)
+			}
+		}
+		return true
+	})
+}
+
+// writeDay creates target and writes files into it, refusing to touch an
+// existing folder. It returns the paths written, in name order.
+func writeDay(target string, files map[string][]byte) ([]string, error) {
+	if _, err := os.Stat(target); err == nil {
+		return nil, fmt.Errorf("%s already exists", target)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	created := make([]string, len(names))
+	for i, name := range names {
+		created[i] = filepath.Join(target, name)
+		if err := os.WriteFile(created[i], files[name], 0o644); err != nil {
+			return created[:i], err
+		}
+	}
+	return created, nil
+}
+
+// addDayImport adds a blank import of path to the import block of the days
+// file, keeping the imports sorted. It does nothing if path is already
+// imported.
+func addDayImport(file, path string) error {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(b), "\n")
+	start := slices.Index(lines, "import (")
+	if start < 0 {
+		return fmt.Errorf("%s: no import block", file)
+	}
+	end := start + 1
+	for end < len(lines) && lines[end] != ")" {
+		end++
+	}
+	if end == len(lines) {
+		return fmt.Errorf("%s: unterminated import block", file)
+	}
+	imports := slices.Clone(lines[start+1 : end])
+	line := "\t_ " + strconv.Quote(path)
+	if slices.Contains(imports, line) {
+		return nil
+	}
+	imports = append(imports, line)
+	slices.Sort(imports)
+	lines = slices.Concat(lines[:start+1], imports, lines[end:])
+	return os.WriteFile(file, []byte(strings.Join(lines, "\n")), 0o644)
+}
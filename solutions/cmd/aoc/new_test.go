@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// newSolutionsDir returns a temporary solutions directory with a days file
+// importing day1-1 and day3-1.
+func newSolutionsDir(t *testing.T) string {
+	dir := t.TempDir()
+	days := filepath.Join(dir, daysFile)
+	if err := os.MkdirAll(filepath.Dir(days), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	src := "package main\n\nimport (\n" +
+		"\t_ \"github.com/nfitbh72/aoc2025/solutions/day1-1\"\n" +
+		"\t_ \"github.com/nfitbh72/aoc2025/solutions/day3-1\"\n" +
+		")\n"
+	if err := os.WriteFile(days, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// readFile returns the contents of a file, failing the test if it is missing.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestNewCommand(t *testing.T) {
+	dir := newSolutionsDir(t)
+	run := func(args ...string) (int, string) {
+		var stdout, stderr bytes.Buffer
+		code := dispatch(append([]string{"new", "-dir", dir, "-templates", filepath.Join("..", "..", "templates")}, args...), &stdout, &stderr)
+		return code, stdout.String() + stderr.String()
+	}
+
+	code, out := run("-title", "Cafeteria", "-tags", "ranges, sorting", "2", "1")
+	eulerlib.CheckTest(t, "aoc.new", eulerlib.TTest{Name: "exit code", Expect: 0}, code)
+	for _, name := range []string{"input-test.txt", "input.txt", "main.go", "main_test.go"} {
+		if !strings.Contains(out, filepath.Join(dir, "day2-1", name)) {
+			t.Errorf("expected %s to be listed as created, got %q", name, out)
+		}
+	}
+	src := readFile(t, filepath.Join(dir, "day2-1", "main.go"))
+	for _, want := range []string{"package day2part1", "Day:   2,", "Part:  1,", `Title: "Cafeteria",`, `Tags:  []string{"ranges", "sorting"},`, `return "Day 2, Part 1"`} {
+		if !strings.Contains(src, want) {
+			t.Errorf("main.go is missing %q:\n%s", want, src)
+		}
+	}
+	if input := readFile(t, filepath.Join(dir, "day2-1", "input.txt")); input != "" {
+		t.Errorf("expected an empty input.txt, got %q", input)
+	}
+
+	days := readFile(t, filepath.Join(dir, daysFile))
+	first := strings.Index(days, "solutions/day1-1")
+	added := strings.Index(days, "solutions/day2-1")
+	last := strings.Index(days, "solutions/day3-1")
+	if added < 0 || !(first < added && added < last) {
+		t.Errorf("expected day2-1 imported between day1-1 and day3-1:\n%s", days)
+	}
+
+	code, out = run("2", "1")
+	eulerlib.CheckTest(t, "aoc.new", eulerlib.TTest{Name: "existing folder", Expect: 1}, code)
+	if !strings.Contains(out, "already exists") {
+		t.Errorf("unexpected error %q", out)
+	}
+	code, _ = run("26", "1")
+	eulerlib.CheckTest(t, "aoc.new", eulerlib.TTest{Name: "invalid day", Expect: 2}, code)
+	code, _ = run("-clone", "2", "1")
+	eulerlib.CheckTest(t, "aoc.new", eulerlib.TTest{Name: "clone part 1", Expect: 2}, code)
+}
+
+func TestNewCommandClone(t *testing.T) {
+	dir := newSolutionsDir(t)
+	source := filepath.Join(dir, "day1-1")
+	if err := os.MkdirAll(filepath.Join(source, "examples"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"main.go", "main_test.go", "input.txt", "input-test.txt", "instructions.txt"} {
+		if err := os.WriteFile(filepath.Join(source, name), []byte(readFile(t, filepath.Join("..", "..", "day1-1", name))), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := dispatch([]string{"new", "-dir", dir, "-clone", "1", "2"}, &stdout, &stderr)
+	eulerlib.CheckTest(t, "aoc.new", eulerlib.TTest{Name: "clone exit code", Expect: 0}, code)
+	if code != 0 {
+		t.Fatal(stderr.String())
+	}
+
+	target := filepath.Join(dir, "day1-2")
+	src := readFile(t, filepath.Join(target, "main.go"))
+	for _, want := range []string{"package day1part2", "Part:  2,", `Title: "Secret Entrance",`, `return "Day 1, Part 2"`, "eulerlib.NewDial(100, 50)"} {
+		if !strings.Contains(src, want) {
+			t.Errorf("cloned main.go is missing %q:\n%s", want, src)
+		}
+	}
+	if strings.Contains(src, `"999"`) || strings.Contains(src, `return "3"`) {
+		t.Errorf("expected part 1's answers to be reset:\n%s", src)
+	}
+	if test := readFile(t, filepath.Join(target, "main_test.go")); !strings.HasPrefix(test, "package day1part2") {
+		t.Errorf("unexpected cloned test package:\n%s", test)
+	}
+	if readFile(t, filepath.Join(target, "input.txt")) != readFile(t, filepath.Join(source, "input.txt")) {
+		t.Error("expected input.txt to be copied unchanged")
+	}
+	for _, name := range []string{"instructions.txt", "examples"} {
+		if _, err := os.Stat(filepath.Join(target, name)); err == nil {
+			t.Errorf("expected %s not to be cloned", name)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// progressLogInterval is how often progress is logged when it is not drawn
+// on a terminal.
+const progressLogInterval = 5 * time.Second
+
+// progressBarWidth is the width of the bars drawn on a terminal.
+const progressBarWidth = 30
+
+// progressFlag is the value of the -progress flag: "tty", "log" or "off".
+// Given without a value it is "auto", choosing tty when stderr is a terminal
+// and log otherwise.
+type progressFlag string
+
+// String returns the flag's value.
+func (f *progressFlag) String() string {
+	return string(*f)
+}
+
+// Set parses a -progress value.
+func (f *progressFlag) Set(s string) error {
+	switch s {
+	case "true", "auto":
+		*f = "auto"
+	case "false", "off":
+		*f = "off"
+	case "tty", "log":
+		*f = progressFlag(s)
+	default:
+		return fmt.Errorf("want tty, log or off")
+	}
+	return nil
+}
+
+// IsBoolFlag lets -progress be given without a value.
+func (f *progressFlag) IsBoolFlag() bool {
+	return true
+}
+
+// newProgress returns the Progress selected by mode, drawing bars on stderr or
+// logging through log, or nil when progress is off.
+func newProgress(mode progressFlag, stderr io.Writer, log *eulerlib.Debugger) *eulerlib.Progress {
+	if mode == "auto" {
+		mode = "log"
+		if isTerminal(stderr) {
+			mode = "tty"
+		}
+	}
+	switch mode {
+	case "tty":
+		return eulerlib.NewProgress(eulerlib.NewTTYRenderer(stderr, progressBarWidth), 0)
+	case "log":
+		return eulerlib.NewProgress(eulerlib.NewLogRenderer(log), progressLogInterval)
+	}
+	return nil
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
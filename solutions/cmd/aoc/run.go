@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// runCommand implements "aoc run", executing the selected problems and
+// printing a table of their answers.
+func runCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	all := fs.Bool("all", false, "run every problem")
+	short := fs.Bool("short", false, "use the short (sample) input and answer")
+	inputs := fs.String("inputs", "", "directory of dayX-Y/ input folders searched before "+eulerlib.InputDirEnv+" and the package directories")
+	fetch := fs.Bool("fetch", false, "download missing inputs into the cache using the "+eulerlib.SessionEnv+" session token")
+	timeout := fs.Duration("timeout", eulerlib.DefaultTimeout, "per-problem time limit (0 for none)")
+	var prof eulerlib.ProfileOptions
+	fs.StringVar(&prof.CPUDir, "cpuprofile", "", "write each problem's CPU profile to `dir`/dayX-Y.cpu.pprof")
+	fs.StringVar(&prof.MemDir, "memprofile", "", "write each problem's heap profile to `dir`/dayX-Y.mem.pprof")
+	fs.StringVar(&prof.TraceDir, "trace", "", "write each problem's execution trace to `dir`/dayX-Y.trace")
+	top := fs.Int("top", 0, "print the `n` functions using the most CPU in each problem")
+	workers := fs.Int("j", 1, "run up to `n` problems at once (0 for one per CPU)")
+	logLevel := fs.String("log", "info", "log `level`: trace, debug, info, warn, error or off")
+	logFormat := fs.String("log-format", "text", "log `format`: text or json")
+	logFile := fs.String("log-file", "", "write logs to `file` instead of stderr")
+	debug := fs.Bool("debug", false, "shorthand for -log debug")
+	progress := progressFlag("off")
+	fs.Var(&progress, "progress", "show progress as `tty` bars, log lines or off; -progress alone picks tty on a terminal and log otherwise")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: aoc run [-short] [-j n] [-log level] [-log-format format] [-log-file file] [-debug] [-progress[=mode]] [-inputs dir] [-fetch] [-timeout d] [-cpuprofile dir] [-memprofile dir] [-trace dir] [-top n] (-all | <day> [part])")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	eulerlib.SetInputDir(*inputs)
+	if *fetch {
+		f, err := inputFetcher()
+		if err != nil {
+			fmt.Fprintln(stderr, "aoc run:", err)
+			return 1
+		}
+		eulerlib.SetInputFetcher(f)
+		defer eulerlib.SetInputFetcher(nil)
+	}
+
+	selected, err := parseSelection(*all, fs.Args())
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc run:", err)
+		fs.Usage()
+		return 2
+	}
+	logOpts, err := parseLogOptions(*logLevel, *logFormat, *debug)
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc run:", err)
+		return 2
+	}
+
+	if *workers != 1 && (prof.Enabled() || *top > 0) {
+		//profiles are process wide, so problems can only be profiled one at a time
+		fmt.Fprintln(stderr, "aoc run: profiling needs -j 1")
+		return 2
+	}
+
+	if *top > 0 && prof.CPUDir == "" {
+		//-top on its own still needs a CPU profile to summarise
+		dir, err := os.MkdirTemp("", "aoc-cpuprofile")
+		if err != nil {
+			fmt.Fprintln(stderr, "aoc run:", err)
+			return 1
+		}
+		defer os.RemoveAll(dir)
+		prof.CPUDir = dir
+	}
+
+	//an interrupt cancels the running problem, and skips the rest, rather than killing the table
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	logOut, closeLog, err := openLog(stderr, *logFile)
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc run:", err)
+		return 1
+	}
+	defer closeLog()
+	//solutions logging through the global debugger go to the same place, until
+	//the command returns
+	global, _ := eulerlib.NewLogger(logOut, logOpts)
+	defer eulerlib.SetLogger(eulerlib.GetDebugger())
+	eulerlib.SetLogger(global)
+
+	opts := eulerlib.RunOptions{Short: *short, Timeout: *timeout, Workers: *workers, Log: logOpts, LogOutput: logOut}
+	opts.Progress = newProgress(progress, stderr, global)
+	if opts.Progress != nil {
+		opts.Progress.Start()
+	}
+	var report eulerlib.RunReport
+	tops := make([]eulerlib.ProfileTop, len(selected))
+	if prof.Enabled() {
+		report = runProfiled(ctx, stderr, selected, opts, prof, *top, tops)
+	} else {
+		report = eulerlib.RunAll(ctx, selected, opts)
+	}
+	if opts.Progress != nil {
+		opts.Progress.Stop()
+	}
+	results := report.Results
+	writeResults(stdout, selected, report)
+	if *top > 0 {
+		for i, r := range results {
+			writeProfileTop(stdout, r.Name, tops[i])
+		}
+	}
+
+	for _, r := range results {
+		if r.Failed() {
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseLogOptions parses the -log and -log-format flags, with -debug
+// lowering the level to at least debug.
+func parseLogOptions(level, format string, debug bool) (eulerlib.LogOptions, error) {
+	var opts eulerlib.LogOptions
+	var err error
+	if opts.Level, err = eulerlib.ParseLevel(level); err != nil {
+		return opts, err
+	}
+	if debug {
+		opts.Level = min(opts.Level, slog.LevelDebug)
+	}
+	opts.Format, err = eulerlib.ParseLogFormat(format)
+	return opts, err
+}
+
+// openLog returns the writer logs go to: file when one is given and stderr
+// otherwise. The writer is safe to share between loggers.
+func openLog(stderr io.Writer, file string) (io.Writer, func(), error) {
+	if file == "" {
+		return eulerlib.SyncWriter(stderr), func() {}, nil
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return eulerlib.SyncWriter(f), func() { f.Close() }, nil
+}
+
+// runProfiled runs the selected problems one at a time, capturing the
+// profiles requested by prof and, when top is positive, reading the top
+// functions of each CPU profile into tops.
+func runProfiled(ctx context.Context, stderr io.Writer, selected []eulerlib.ProblemInfo, opts eulerlib.RunOptions,
+	prof eulerlib.ProfileOptions, top int, tops []eulerlib.ProfileTop) eulerlib.RunReport {
+	report := eulerlib.RunReport{Results: make([]eulerlib.RunResult, len(selected)), Workers: 1}
+	start := time.Now()
+	for i, info := range selected {
+		files, err := eulerlib.Profile(prof, eulerlib.ProfileName(info, opts.Short), func() {
+			report.Results[i] = eulerlib.RunInfo(ctx, info, opts)
+		})
+		if err != nil {
+			fmt.Fprintf(stderr, "aoc run: profiling %s: %v\n", info, err)
+		}
+		if top > 0 && files.CPU != "" {
+			if tops[i], err = eulerlib.ReadProfileTop(files.CPU, top); err != nil {
+				fmt.Fprintf(stderr, "aoc run: reading %s: %v\n", files.CPU, err)
+			}
+		}
+		report.Summed += report.Results[i].Duration
+	}
+	report.Wall = time.Since(start)
+	return report
+}
+
+// parseSelection resolves the -all flag and positional day/part arguments to
+// the registered problems to run.
+func parseSelection(all bool, args []string) ([]eulerlib.ProblemInfo, error) {
+	if all {
+		if len(args) > 0 {
+			return nil, errors.New("-all cannot be combined with a day or part")
+		}
+		return eulerlib.DefaultRegistry().All(), nil
+	}
+	if len(args) == 0 || len(args) > 2 {
+		return nil, errors.New("expected -all or <day> [part]")
+	}
+	day, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid day %q", args[0])
+	}
+	part := 0
+	if len(args) == 2 {
+		part, err = strconv.Atoi(args[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid part %q", args[1])
+		}
+	}
+	selected := eulerlib.DefaultRegistry().Select(day, part)
+	if len(selected) == 0 {
+		if part == 0 {
+			return nil, fmt.Errorf("no solution for day %d", day)
+		}
+		return nil, fmt.Errorf("no solution for day %d, part %d", day, part)
+	}
+	return selected, nil
+}
+
+// writeResults prints one row per result followed by a pass/fail summary
+// comparing the wall time with the time the problems took between them.
+func writeResults(w io.Writer, selected []eulerlib.ProblemInfo, report eulerlib.RunReport) {
+	results := report.Results
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tNAME\tANSWER\tEXPECTED\tRESULT\tTIME")
+	passed, unknown := 0, 0
+	for i, r := range results {
+		answer := tableAnswer(r.Answer)
+		if r.Err != nil {
+			answer = r.Err.Error()
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			selected[i].Day, selected[i].Part, r.Name, answer, tableAnswer(r.Expected), r.Status(), formatDuration(r.Duration))
+		if r.Passed() {
+			passed++
+		}
+		if r.Unknown() {
+			unknown++
+		}
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\n%d passed, %d failed, %d unknown in %s (%s of problem time on %s)\n",
+		passed, len(results)-passed-unknown, unknown, formatDuration(report.Wall), formatDuration(report.Summed), plural(report.Workers, "worker"))
+}
+
+// writeProfileTop prints the functions that used the most CPU in one
+// problem, in the style of "go tool pprof -top".
+func writeProfileTop(w io.Writer, name string, top eulerlib.ProfileTop) {
+	fmt.Fprintf(w, "\n%s: %s of CPU sampled\n", name, formatDuration(time.Duration(top.Total)))
+	if len(top.Entries) == 0 {
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "FLAT\tFLAT%\tCUM\tCUM%\t\tFUNCTION")
+	for _, e := range top.Entries {
+		fmt.Fprintf(tw, "%s\t%.1f%%\t%s\t%.1f%%\t\t%s\n",
+			formatDuration(time.Duration(e.Flat)), percent(e.Flat, top.Total),
+			formatDuration(time.Duration(e.Cum)), percent(e.Cum, top.Total), e.Function)
+	}
+	tw.Flush()
+}
+
+// percent returns part as a percentage of total.
+func percent(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
+
+// tableAnswer formats an answer for a single table cell, joining multi-line
+// answers with a visible separator.
+func tableAnswer(a eulerlib.Answer) string {
+	return strings.Join(a.Lines(), " / ")
+}
+
+// formatDuration rounds a duration to a readable precision for the table.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+// plural formats a count with a noun, adding an "s" unless n is one.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+// submitCommand implements "aoc submit", posting an answer to the site once
+// the answer history and manifest show it is worth trying, and recording the
+// verdict in both.
+func submitCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "", "answers manifest (default: the nearest "+eulerlib.AnswersFile+" above the solutions)")
+	history := fs.String("history", "", "answer history (default: "+eulerlib.HistoryFile+" beside the answers manifest)")
+	url := fs.String("url", eulerlib.DefaultFetchURL, "site to submit to")
+	timeout := fs.Duration("timeout", eulerlib.DefaultTimeout, "time limit when the solution is run for the answer (0 for none)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: aoc submit [-file path] [-history path] [-url url] [-timeout d] <day> <part> [answer]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() < 2 || fs.NArg() > 3 {
+		fmt.Fprintln(stderr, "aoc submit: expected <day> <part> [answer]")
+		fs.Usage()
+		return 2
+	}
+	info, err := parseDayPart(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc submit:", err)
+		return 2
+	}
+
+	result, err := submit(stdout, info, fs.Arg(2), submitOptions{file: *file, history: *history, url: *url, timeout: *timeout})
+	if err != nil {
+		fmt.Fprintln(stderr, "aoc submit:", err)
+		return 1
+	}
+	if result.Verdict != eulerlib.VerdictCorrect {
+		return 1
+	}
+	return 0
+}
+
+// submitOptions holds the flags of "aoc submit".
+type submitOptions struct {
+	file    string
+	history string
+	url     string
+	timeout time.Duration
+}
+
+// submit checks and submits an answer for info, running the solution for it
+// when answerArg is empty, and records the verdict.
+func submit(stdout io.Writer, info eulerlib.ProblemInfo, answerArg string, opts submitOptions) (eulerlib.SubmitResult, error) {
+	var result eulerlib.SubmitResult
+	answer := eulerlib.ParseAnswer(answerArg)
+	if answer.IsEmpty() {
+		run := eulerlib.RunInfo(context.Background(), info, eulerlib.RunOptions{Timeout: opts.timeout})
+		if run.Err != nil {
+			return result, fmt.Errorf("running %s: %w", info, run.Err)
+		}
+		answer = run.Answer
+		fmt.Fprintf(stdout, "%s answered %s in %s\n", info, answer, run.Duration.Round(time.Millisecond))
+	}
+
+	lines, err := info.LoadInput(false)
+	if err != nil {
+		return result, err
+	}
+	hash := eulerlib.HashInput(lines)
+	manifestFile := manifestPath(opts.file, info)
+	manifest, err := eulerlib.LoadManifest(manifestFile)
+	if err != nil {
+		return result, err
+	}
+	if r, ok := manifest.Lookup(info.Year, info.Day, info.Part, hash); ok {
+		if r.Status == eulerlib.AnswerVerified {
+			return result, fmt.Errorf("%s already has the verified answer %s", info, r.Answer)
+		}
+		if r.IsWrong(answer) {
+			return result, fmt.Errorf("%s was recorded as a wrong answer for %s", answer, info)
+		}
+	}
+	historyFile := opts.history
+	if historyFile == "" {
+		historyFile = filepath.Join(filepath.Dir(manifestFile), eulerlib.HistoryFile)
+	}
+	history, err := eulerlib.LoadHistory(historyFile)
+	if err != nil {
+		return result, err
+	}
+	if err := history.Check(info.Year, info.Day, info.Part, answer, time.Now()); err != nil {
+		return result, err
+	}
+
+	session, err := eulerlib.LoadSession()
+	if err != nil {
+		return result, err
+	}
+	f := eulerlib.NewFetcher(session)
+	f.BaseURL = opts.url
+	sent := time.Now()
+	result, err = f.Submit(context.Background(), info.Year, info.Day, info.Part, answer)
+	if err != nil {
+		return result, err
+	}
+	fmt.Fprintf(stdout, "%s: %s\n%s\n", answer, result.Verdict, result.Message)
+
+	attempt := eulerlib.Attempt{Time: sent, Year: info.Year, Day: info.Day, Part: info.Part, Answer: answer, Verdict: result.Verdict, Wait: result.Wait, Message: result.Message}
+	err = history.Record(attempt)
+	switch {
+	case result.Verdict == eulerlib.VerdictCorrect:
+		err = errors.Join(err, manifest.Verify(info.Year, info.Day, info.Part, hash, answer), manifest.Save())
+	case result.Verdict.IsWrong():
+		err = errors.Join(err, manifest.AddWrong(info.Year, info.Day, info.Part, hash, answer), manifest.Save())
+	}
+	return result, err
+}
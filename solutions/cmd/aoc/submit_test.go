@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
+)
+
+func TestSubmitCommand(t *testing.T) {
+	submitted := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		answer := r.PostFormValue("answer")
+		submitted = append(submitted, answer)
+		switch n, _ := strconv.Atoi(answer); {
+		case n == 999:
+			w.Write([]byte("<article><p>That's the right answer!  You are one gold star closer.</p></article>"))
+		case n < 999:
+			w.Write([]byte("<article><p>That's not the right answer; your answer is too low.</p></article>"))
+		default:
+			w.Write([]byte("<article><p>That's not the right answer; your answer is too high.</p></article>"))
+		}
+	}))
+	defer server.Close()
+	t.Setenv(eulerlib.SessionEnv, "secret")
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, eulerlib.AnswersFile)
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := dispatch(append([]string{"submit", "-file", manifest, "-url", server.URL}, args...), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, _ := run("1", "1", "998")
+	eulerlib.CheckTest(t, "aoc.submit", eulerlib.TTest{Name: "too low", Expect: 1}, code)
+	if !strings.Contains(out, "998: too low") {
+		t.Errorf("unexpected output %q", out)
+	}
+	code, _, errOut := run("1", "1", "997")
+	eulerlib.CheckTest(t, "aoc.submit", eulerlib.TTest{Name: "below bound", Expect: 1}, code)
+	if !strings.Contains(errOut, "998 already was") {
+		t.Errorf("unexpected error %q", errOut)
+	}
+	code, _, errOut = run("1", "1", "998")
+	eulerlib.CheckTest(t, "aoc.submit", eulerlib.TTest{Name: "known wrong", Expect: 1}, code)
+	if !strings.Contains(errOut, "recorded as a wrong answer") {
+		t.Errorf("unexpected error %q", errOut)
+	}
+	code, out, _ = run("1", "1")
+	eulerlib.CheckTest(t, "aoc.submit", eulerlib.TTest{Name: "correct", Expect: 0}, code)
+	if !strings.Contains(out, "answered 999") || !strings.Contains(out, "999: correct") {
+		t.Errorf("unexpected output %q", out)
+	}
+	code, _, _ = run("1", "1", "1000")
+	eulerlib.CheckTest(t, "aoc.submit", eulerlib.TTest{Name: "already verified", Expect: 1}, code)
+	eulerlib.CheckTest(t, "aoc.submit", eulerlib.TTest{Name: "submitted", Expect: []string{"998", "999"}}, submitted)
+
+	history, err := eulerlib.LoadHistory(filepath.Join(dir, eulerlib.HistoryFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	eulerlib.CheckTest(t, "aoc.submit", eulerlib.TTest{Name: "history", Expect: 2}, len(history.Attempts))
+	m, err := eulerlib.LoadManifest(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Records) != 1 || m.Records[0].Status != eulerlib.AnswerVerified || m.Records[0].Answer.String() != "999" {
+		t.Errorf("unexpected manifest %+v", m.Records)
+	}
+	code, _, _ = run("1")
+	eulerlib.CheckTest(t, "aoc.submit", eulerlib.TTest{Name: "usage", Expect: 2}, code)
+}
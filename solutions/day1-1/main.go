@@ -1,13 +1,22 @@
-package main
+package day1part1
 
 import (
-	"fmt"
-
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
 )
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   1,
+		Part:  1,
+		Title: "Secret Entrance",
+		Tags:  []string{"simulation"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -19,7 +28,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -27,7 +36,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 func (m *Problem) Solve(lines []string) int {
@@ -37,7 +46,7 @@ func (m *Problem) Solve(lines []string) int {
 		//direction L or R
 		dir := line[:1]
 		//number of clicks to turn
-		numClicks := eulerlib.StrToInt(line[1:])
+		numClicks := m.Conv().StrToInt(line[1:])
 
 		if dir == "L" {
 			d.Left(numClicks)
@@ -51,10 +60,3 @@ func (m *Problem) Solve(lines []string) int {
 	//of each turn
 	return val
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
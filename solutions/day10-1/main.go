@@ -1,7 +1,6 @@
-package main
+package day10part1
 
 import (
-	"fmt"
 	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -11,16 +10,26 @@ type Problem struct {
 	eulerlib.Problem
 }
 
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   10,
+		Part:  1,
+		Title: "Factory",
+		Tags:  []string{"search"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
+}
+
 func (m *Problem) GetProblemName() string {
 	return "Day 10, Part 1"
 }
 
 func (m *Problem) GetAnswer() string {
-	return "4725826296"
+	return ""
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,7 +37,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 type TMachine struct {
@@ -37,36 +46,29 @@ type TMachine struct {
 	//Joltage []int
 }
 
-func (m *Problem) NewMachine(line string) TMachine {
-	machine := TMachine{}
-	machine.LightsNeeded = make([]bool, 0)
-	machine.Buttons = make([][]int, 0)
-	parts := strings.Split(line, "]")
-	machineString := parts[0][1:]
-	for _, c := range machineString {
-		if c == '#' {
-			machine.LightsNeeded = append(machine.LightsNeeded, true)
-		} else {
-			machine.LightsNeeded = append(machine.LightsNeeded, false)
+// machineParser reads a machine such as "[.##.] (3) (1,3) {3,5,4,7}". The
+// joltage is not needed for the lights.
+var machineParser = eulerlib.Seq3(
+	eulerlib.Between(eulerlib.Lit("["), eulerlib.Many(eulerlib.OneOf(".#")), eulerlib.Lit("]")),
+	eulerlib.Preceded(eulerlib.Spaces(), eulerlib.SepBy(intList("(", ")"), eulerlib.Spaces())),
+	eulerlib.Preceded(eulerlib.Spaces(), intList("{", "}")),
+	func(lights []rune, buttons [][]int, _ []int) TMachine {
+		machine := TMachine{Buttons: buttons}
+		for _, c := range lights {
+			machine.LightsNeeded = append(machine.LightsNeeded, c == '#')
 		}
-	}
-	buttonPartsOne := strings.Split(parts[1], "{")
-	buttonParts := strings.Split(buttonPartsOne[0], ")")
-	for _, buttonsStr := range buttonParts {
-		buttonBits := strings.Split(buttonsStr, "(")
-		//fmt.Printf("1: \"%s\"\n", buttonBits)
-		//fmt.Println(reflect.TypeOf(buttonBits))
-		if len(buttonBits) > 1 {
-			reallyTheButtonBits := strings.Split(buttonBits[1], ",")
-			//fmt.Printf("3: \"%s\"\n", reallyTheButtonBits)
-			buttons := make([]int, 0)
-			for _, buttonBit := range reallyTheButtonBits {
-				buttons = append(buttons, eulerlib.StrToInt(buttonBit))
-			}
-			machine.Buttons = append(machine.Buttons, buttons)
-		}
-	}
-	return machine
+		return machine
+	},
+)
+
+// intList reads comma-separated integers between open and close.
+func intList(open, close string) eulerlib.Parser[[]int] {
+	return eulerlib.Between(eulerlib.Lit(open), eulerlib.SepBy(eulerlib.Int(), eulerlib.Lit(",")), eulerlib.Lit(close))
+}
+
+// ParseMachines reads one machine per line, panicking on malformed input.
+func (m *Problem) ParseMachines(lines []string) []TMachine {
+	return eulerlib.MustParse(eulerlib.Lines(machineParser), strings.Join(lines, "\n"))
 }
 
 // IncrementBase increments a number in a given base
@@ -131,21 +133,10 @@ func (m *TMachine) CheckLights(buttonPresses []int) bool {
 }
 
 func (m *Problem) Solve(lines []string) int {
-	machines := []TMachine{}
-	for _, line := range lines {
-		machine := m.NewMachine(line)
-		machines = append(machines, machine)
-	}
+	machines := m.ParseMachines(lines)
 	sum := 0
 	for _, machine := range machines {
 		sum += machine.SolveLights()
 	}
 	return sum
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
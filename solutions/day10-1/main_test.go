@@ -1,4 +1,4 @@
-package main
+package day10part1
 
 import (
 	"testing"
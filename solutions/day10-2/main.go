@@ -1,10 +1,9 @@
-package main
+package day10part2
 
 import (
+	"context"
 	"fmt"
-	"strings"
 	"sync"
-	"time"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
 )
@@ -13,16 +12,26 @@ type Problem struct {
 	eulerlib.Problem
 }
 
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   10,
+		Part:  2,
+		Title: "Factory",
+		Tags:  []string{"search"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
+}
+
 func (m *Problem) GetProblemName() string {
 	return "Day 10, Part 2"
 }
 
 func (m *Problem) GetAnswer() string {
-	return "4725826296"
+	return ""
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.AnswerToStr(m.SolveContext(context.Background(), false))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -30,7 +39,21 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.AnswerToStr(m.SolveContext(context.Background(), true))
+}
+
+// SolveContext solves the full or short input, abandoning the search when ctx
+// is cancelled.
+func (m *Problem) SolveContext(ctx context.Context, short bool) (eulerlib.Answer, error) {
+	filename := "input.txt"
+	if short {
+		filename = "input-test.txt"
+	}
+	lines, err := eulerlib.LoadInput(filename)
+	if err != nil {
+		return eulerlib.Answer{}, err
+	}
+	return m.SolveInput(ctx, lines)
 }
 
 const (
@@ -53,46 +76,36 @@ type TMachine struct {
 	TotalPresses               int
 	Status                     int
 	mu                         sync.Mutex
+	cancelled                  <-chan struct{}
+	debug                      *eulerlib.Debugger
 }
 
-func (m *Problem) NewMachine(line string) *TMachine {
-	machine := TMachine{}
-	//machine.LightsNeeded = make([]bool, 0)
-	//machine.Buttons = make([][]int, 0)
-	parts := strings.Split(line, "]")
-	/*
-		machineString := parts[0][1:]
-			for _, c := range machineString {
-				if c == '#' {
-					machine.LightsNeeded = append(machine.LightsNeeded, true)
-				} else {
-					machine.LightsNeeded = append(machine.LightsNeeded, false)
-				}
-			}
-	*/
-	buttonPartsOne := strings.Split(parts[1], "{")
-	buttonParts := strings.Split(buttonPartsOne[0], ")")
-	for _, buttonsStr := range buttonParts {
-		buttonBits := strings.Split(buttonsStr, "(")
-		//fmt.Printf("1: \"%s\"\n", buttonBits)
-		//fmt.Println(reflect.TypeOf(buttonBits))
-		if len(buttonBits) > 1 {
-			reallyTheButtonBits := strings.Split(buttonBits[1], ",")
-			//fmt.Printf("3: \"%s\"\n", reallyTheButtonBits)
-			buttons := make([]int, 0)
-			for _, buttonBit := range reallyTheButtonBits {
-				buttons = append(buttons, eulerlib.StrToInt(buttonBit))
-			}
-			machine.Buttons = append(machine.Buttons, buttons)
-		}
-	}
-	//fmt.Println("\"", buttonPartsOne[1], "\"")
-	joltageParts := buttonPartsOne[1][:len(buttonPartsOne[1])-1]
-	//fmt.Println(joltageParts)
-	machine.JoltageNeeded = make([]int, 0)
-	for _, joltageStr := range strings.Split(joltageParts, ",") {
-		machine.JoltageNeeded = append(machine.JoltageNeeded, eulerlib.StrToInt(joltageStr))
-	}
+// machineSpec is a machine as written in the input.
+type machineSpec struct {
+	buttons [][]int
+	joltage []int
+}
+
+// machineParser reads a machine such as "[.##.] (3) (1,3) {3,5,4,7}". The
+// lights are not needed for the joltage.
+var machineParser = eulerlib.Seq3(
+	eulerlib.Between(eulerlib.Lit("["), eulerlib.Regexp(`[.#]*`), eulerlib.Lit("]")),
+	eulerlib.Preceded(eulerlib.Spaces(), eulerlib.SepBy(intList("(", ")"), eulerlib.Spaces())),
+	eulerlib.Preceded(eulerlib.Spaces(), intList("{", "}")),
+	func(_ string, buttons [][]int, joltage []int) machineSpec {
+		return machineSpec{buttons: buttons, joltage: joltage}
+	},
+)
+
+// intList reads comma-separated integers between open and close.
+func intList(open, close string) eulerlib.Parser[[]int] {
+	return eulerlib.Between(eulerlib.Lit(open), eulerlib.SepBy(eulerlib.Int(), eulerlib.Lit(",")), eulerlib.Lit(close))
+}
+
+func (m *Problem) NewMachine(buttons [][]int, joltage []int) *TMachine {
+	machine := TMachine{debug: eulerlib.GetDebugger()}
+	machine.Buttons = buttons
+	machine.JoltageNeeded = joltage
 
 	// Calculate upper bound for each button
 	machine.maxPresses = make([]int, len(machine.Buttons))
@@ -225,6 +238,11 @@ func (m *TMachine) SmashButtons(buttonNumber int, buttonPressesOriginal []int, c
 	// iterate to max presses + 1
 	// possible combo is that the below buttons could be the correct joltage when this button is pressed 0 times or when max presses is +1
 	for i := range m.maxPresses[buttonNumber] + 1 {
+		select {
+		case <-m.cancelled:
+			return
+		default:
+		}
 
 		// check buttons below before pressing, possible combo is that this button is pressed 0 times or when max presses is +1
 		if buttonNumber < len(m.Buttons)-1 {
@@ -240,154 +258,72 @@ func (m *TMachine) SmashButtons(buttonNumber int, buttonPressesOriginal []int, c
 		}
 		buttonPresses[buttonNumber]++
 		m.TotalPresses++
-		if eulerlib.GetDebugger().IsDebug() {
-			fmt.Println(m.MachineNumber, buttonNumber, "pressed button", buttonPresses, currentJoltage)
-		}
+		m.debug.Log(m.MachineNumber, buttonNumber, "pressed button", buttonPresses, currentJoltage)
 
 		// check on the status of our joltages
 		status := m.JoltageCompare(currentJoltage)
 
 		// success, we found one solution
 		if status == STATUS_EQUAL_JOLTAGE {
-			if eulerlib.GetDebugger().IsDebug() {
-				fmt.Println(m.MachineNumber, buttonNumber, "FOUND!")
-			}
+			m.debug.Log(m.MachineNumber, buttonNumber, "FOUND!")
 
 			// note: did have mutex/lock code here, but realised that each *machine* is in it's own go func,
 			// therefore this is not actually shared data
 
 			// is this the smallest solution found so far for this machine?
 			if m.GetTotalButtonPresses(buttonPresses) < m.SmallestButtonPressesCount {
-				if eulerlib.GetDebugger().IsDebug() {
-					fmt.Println(m.MachineNumber, buttonNumber, "AND IT'S SMALLEST")
-				}
+				m.debug.Log(m.MachineNumber, buttonNumber, "AND IT'S SMALLEST")
 				m.SmallestButtonPressesCount = m.GetTotalButtonPresses(buttonPresses)
 				copy(m.SmallestButtonPresses, buttonPresses)
 				m.AnyFound = true
 			}
 
-			if eulerlib.GetDebugger().IsDebug() {
-				fmt.Println(m.MachineNumber, buttonNumber, "returning because equal")
-			}
+			m.debug.Log(m.MachineNumber, buttonNumber, "returning because equal")
 			return
 		}
 
 		if status == STATUS_OVER_JOLTAGE {
-			if eulerlib.GetDebugger().IsDebug() {
-				fmt.Println(m.MachineNumber, buttonNumber, "returning because over")
-			}
+			m.debug.Log(m.MachineNumber, buttonNumber, "returning because over")
 			return
 		}
 
 	}
-	if eulerlib.GetDebugger().IsDebug() {
-		fmt.Println(m.MachineNumber, buttonNumber, "returning because no match")
-	}
+	m.debug.Log(m.MachineNumber, buttonNumber, "returning because no match")
 }
 
-func displayProgress(machines []*TMachine, done chan bool) {
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-done:
-			return
-		case <-ticker.C:
-			// Clear screen and move to top
-			fmt.Print("\033[2J\033[H")
-
-			// Calculate max total presses for scaling
-			maxPresses := 1
-			for _, m := range machines {
-				if m.TotalPresses > maxPresses {
-					maxPresses = m.TotalPresses
-				}
-			}
-
-			// Display in 3 columns
-			const termWidth = 160
-			const numCols = 3
-			const colWidth = termWidth / numCols
-
-			numRows := (len(machines) + numCols - 1) / numCols
-
-			for row := 0; row < numRows; row++ {
-				for col := 0; col < numCols; col++ {
-					idx := col*numRows + row
-					if idx >= len(machines) {
-						continue
-					}
-
-					m := machines[idx]
-					status := m.GetStatus()
-
-					// Format: "M123 "
-					prefix := fmt.Sprintf("M%-3d ", m.MachineNumber)
-
-					var display string
-					if status == StatusSuccess {
-						display = prefix + "OK"
-					} else if status == StatusFailed {
-						display = prefix + "FAIL"
-					} else {
-						// In progress - show progress bar
-						barWidth := colWidth - len(prefix) - 2
-						numHashes := 0
-						if maxPresses > 0 {
-							numHashes = (m.TotalPresses * barWidth) / maxPresses
-						}
-						if numHashes > barWidth {
-							numHashes = barWidth
-						}
-						bar := ""
-						for i := 0; i < numHashes; i++ {
-							bar += "#"
-						}
-						display = prefix + bar
-					}
-
-					// Pad to column width
-					for len(display) < colWidth {
-						display += " "
-					}
-					fmt.Print(display)
-				}
-				fmt.Println()
-			}
+func (m *Problem) Solve(lines []string) int {
+	sum, _ := m.solve(context.Background(), lines)
+	return sum
+}
 
-			// Display summary
-			inProgress := 0
-			succeeded := 0
-			failed := 0
-			for _, m := range machines {
-				switch m.GetStatus() {
-				case StatusInProgress:
-					inProgress++
-				case StatusSuccess:
-					succeeded++
-				case StatusFailed:
-					failed++
-				}
-			}
-			fmt.Printf("\nTotal: %d | In Progress: %d | Success: %d | Failed: %d\n",
-				len(machines), inProgress, succeeded, failed)
-		}
+// SolveInput solves the given machines, returning ctx's error if it is
+// cancelled before all of them finish.
+func (m *Problem) SolveInput(ctx context.Context, lines []string) (eulerlib.Answer, error) {
+	sum, err := m.solve(ctx, lines)
+	if err != nil {
+		return eulerlib.Answer{}, err
 	}
+	return eulerlib.IntAnswer(sum), nil
 }
 
-func (m *Problem) Solve(lines []string) int {
+// solve searches every machine in parallel.
+func (m *Problem) solve(ctx context.Context, lines []string) (int, error) {
+	specs, err := eulerlib.ParseInput(eulerlib.Lines(machineParser), lines)
+	if err != nil {
+		return 0, err
+	}
 	machines := []*TMachine{}
-	for i, line := range lines {
-		machine := m.NewMachine(line)
+	for i, spec := range specs {
+		machine := m.NewMachine(spec.buttons, spec.joltage)
 		machine.MachineNumber = i
+		machine.cancelled = ctx.Done()
+		machine.debug = eulerlib.DebuggerFrom(ctx)
 		machine.SetStatus(StatusInProgress)
 		machines = append(machines, machine)
 	}
 
-	// Start progress display goroutine
-	done := make(chan bool)
-	go displayProgress(machines, done)
+	progress := eulerlib.ProgressFrom(ctx).Task("machines", int64(len(machines)))
+	defer progress.Done()
 
 	// Use WaitGroup to wait for all goroutines to complete
 	var wg sync.WaitGroup
@@ -416,29 +352,18 @@ func (m *Problem) Solve(lines []string) int {
 			} else {
 				machine.SetStatus(StatusFailed)
 			}
+			progress.Increment()
 		}(i)
 	}
 
 	// Wait for all goroutines to finish
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
 
-	// Stop progress display
-	done <- true
-
-	// Final display
-	fmt.Print("\033[2J\033[H")
-	fmt.Printf("All machines completed!\n")
-	fmt.Printf("Total machines: %d\n", len(machines))
-	fmt.Printf("Successful: %d\n", countFound)
-	fmt.Printf("Failed: %d\n", len(machines)-countFound)
-	fmt.Printf("Sum of button presses: %d\n", sum)
-
-	return sum
-}
+	eulerlib.DebuggerFrom(ctx).Info("all machines completed", "machines", len(machines),
+		"successful", countFound, "failed", len(machines)-countFound, "presses", sum)
 
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
+	return sum, nil
 }
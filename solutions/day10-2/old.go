@@ -1,4 +1,4 @@
-package main
+package day10part2
 
 // IncrementBase increments a number in a given base
 // digits is a slice where each element is 0 to base-1
@@ -1,7 +1,6 @@
-package main
+package day11part1
 
 import (
-	"fmt"
 	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,6 +8,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   11,
+		Part:  1,
+		Title: "Reactor",
+		Tags:  []string{"graph"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -20,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,14 +38,16 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 type TServer struct {
 	devices map[string][]string
+	log     *eulerlib.Debugger
 }
 
 func (m *TServer) Init() {
+	m.log = eulerlib.GetDebugger().Scope("server")
 	m.devices = make(map[string][]string, 0)
 }
 
@@ -51,6 +63,7 @@ func (m *TServer) LoadConnection(forDevice, toDevice string) {
 func (m *Problem) ParseInput(lines []string) *TServer {
 	s := TServer{}
 	s.Init()
+	s.log = m.Logger().Scope("server")
 	for _, line := range lines {
 		parts := strings.Split(line, ": ")
 		label := parts[0]
@@ -68,9 +81,8 @@ func (m *Problem) ParseInput(lines []string) *TServer {
 }
 
 func (m *TServer) Display() {
-	fmt.Println("Server:")
 	for k, d := range m.devices {
-		fmt.Println(k, d)
+		m.log.Debug("device", "label", k, "connections", d)
 	}
 }
 
@@ -96,13 +108,8 @@ func (m *TServer) GetNumPaths(fromStr, toStr string) int {
 
 func (m *Problem) Solve(lines []string) int {
 	server := m.ParseInput(lines)
-	server.Display()
+	if server.log.IsDebug() {
+		server.Display()
+	}
 	return server.GetNumPaths("you", "out")
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
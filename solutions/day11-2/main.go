@@ -1,7 +1,7 @@
-package main
+package day11part2
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,6 +9,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   11,
+		Part:  2,
+		Title: "Reactor",
+		Tags:  []string{"graph"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -16,11 +27,11 @@ func (m *Problem) GetProblemName() string {
 }
 
 func (m *Problem) GetAnswer() string {
-	return "4725826296"
+	return ""
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.AnswerToStr(m.SolveContext(context.Background(), false))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,7 +39,25 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.AnswerToStr(m.SolveContext(context.Background(), true))
+}
+
+// SolveContext solves the full or short input, abandoning the route search
+// when ctx is cancelled.
+func (m *Problem) SolveContext(ctx context.Context, short bool) (eulerlib.Answer, error) {
+	filename := "input.txt"
+	if short {
+		filename = "input-test.txt"
+	}
+	lines, err := eulerlib.LoadInput(filename)
+	if err != nil {
+		return eulerlib.Answer{}, err
+	}
+	numPaths, err := m.solve(ctx, lines)
+	if err != nil {
+		return eulerlib.Answer{}, err
+	}
+	return eulerlib.IntAnswer(numPaths), nil
 }
 
 type TRoutes struct {
@@ -42,9 +71,14 @@ type TServer struct {
 	cache        map[string]TRoutes
 	numCacheHits int
 	UseCache     bool
+	log          *eulerlib.Debugger
+	progress     *eulerlib.Task
+	cancelled    <-chan struct{}
 }
 
 func (m *TServer) Init(dest string) {
+	m.log = eulerlib.GetDebugger().Scope("server")
+	m.progress = eulerlib.NewTask("routes", 0)
 	m.destination = dest
 	m.devices = make(map[string][]string, 0)
 	m.cache = make(map[string]TRoutes)
@@ -64,6 +98,8 @@ func (m *TServer) LoadConnection(forDevice, toDevice string) {
 func (m *Problem) ParseInput(lines []string, startAt string) *TServer {
 	s := TServer{}
 	s.Init(startAt)
+	s.log = m.Logger().Scope("server")
+	s.progress = m.Progress().Task("routes", 0)
 	for _, line := range lines {
 		parts := strings.Split(line, ": ")
 		label := parts[0]
@@ -81,9 +117,8 @@ func (m *Problem) ParseInput(lines []string, startAt string) *TServer {
 }
 
 func (m *TServer) Display() {
-	fmt.Println("Server:")
 	for k, d := range m.devices {
-		fmt.Println(k, d)
+		m.log.Debug("device", "label", k, "connections", d)
 	}
 }
 
@@ -101,7 +136,13 @@ func (m *TServer) AddToCache(from string, result TRoutes) {
 }
 
 func (m *TServer) GetRoutes(from string) TRoutes {
+	select {
+	case <-m.cancelled:
+		return TRoutes{}
+	default:
+	}
 	m.numChecks++
+	m.progress.Increment()
 
 	if m.UseCache {
 		cachedRoutes := m.GetCache(from)
@@ -127,10 +168,6 @@ func (m *TServer) GetRoutes(from string) TRoutes {
 	if m.UseCache {
 		m.AddToCache(from, routes)
 	}
-
-	if m.numChecks%10000000 == 0 {
-		fmt.Println("checks", m.numChecks, "hits", m.numCacheHits)
-	}
 	return routes
 }
 
@@ -174,43 +211,41 @@ func (m *TServer) GetRoutes(from string) TRoutes {
 	}
 */
 func (m *Problem) Solve(lines []string) int {
+	numPaths, _ := m.solve(context.Background(), lines)
+	return numPaths
+}
+
+// solve counts the routes from svr through dac and fft, returning ctx's error
+// if it is cancelled first.
+func (m *Problem) solve(ctx context.Context, lines []string) (int, error) {
 	server := m.ParseInput(lines, "out")
+	server.cancelled = ctx.Done()
 
-	fmt.Println("Server loaded, finding routes")
+	log := m.Logger()
+	log.Info("server loaded, finding routes")
 	//server.UseCache = true
 	routes := server.GetRoutes("svr")
-	if eulerlib.GetDebugger().IsDebug() {
+	server.progress.Done()
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if log.IsDebug() {
 		server.Display()
-		fmt.Println()
-		fmt.Println("routes:")
 		for _, r := range routes.routes {
-			fmt.Println(r)
+			log.Debug("route", "route", r)
 		}
 
 		for k, c := range server.cache {
-			fmt.Println(k, ":")
-			for _, r := range c.routes {
-				fmt.Println("\t", r)
-			}
+			log.Debug("cached routes", "from", k, "routes", c.routes)
 		}
-		fmt.Println()
 	}
 
 	numPaths := 0
 	for _, route := range routes.routes {
 		if strings.Contains(route, "dac") && strings.Contains(route, "fft") {
 			numPaths++
-			if eulerlib.GetDebugger().IsDebug() {
-				fmt.Println("Route with dac and fft:", route)
-			}
+			log.Debug("route with dac and fft", "route", route)
 		}
 	}
-	return numPaths
-}
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
+	return numPaths, nil
 }
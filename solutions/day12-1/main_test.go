@@ -1,4 +1,4 @@
-package main
+package day12part1
 
 import (
 	"testing"
@@ -1,7 +1,6 @@
-package main
+package day2part1
 
 import (
-	"fmt"
 	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,6 +8,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   2,
+		Part:  1,
+		Title: "Gift Shop",
+		Tags:  []string{"strings"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -20,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,7 +38,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 func (m *Problem) IsRepeating(id int) bool {
@@ -48,8 +58,8 @@ func (m *Problem) Solve(lines []string) int {
 	for _, code := range codes {
 		//ids are hyphen separated
 		ids := strings.Split(code, "-")
-		left := eulerlib.StrToInt(ids[0])
-		right := eulerlib.StrToInt(ids[1])
+		left := m.Conv().StrToInt(ids[0])
+		right := m.Conv().StrToInt(ids[1])
 		//loop over the range to find repeating strings within the ids
 		for check := left; check <= right; check++ {
 			if m.IsRepeating(check) {
@@ -61,9 +71,3 @@ func (m *Problem) Solve(lines []string) int {
 	//day 2, part 1 answer is the sum of all repeating strings within the ids
 	return sum
 }
-
-func main() {
-	d := Problem{}
-	fmt.Println(d.GenerateAnswer())
-
-}
@@ -1,7 +1,6 @@
-package main
+package day2part2
 
 import (
-	"fmt"
 	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,6 +8,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   2,
+		Part:  2,
+		Title: "Gift Shop",
+		Tags:  []string{"strings"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -20,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,7 +38,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 func (m *Problem) Solve(lines []string) int {
@@ -38,8 +48,8 @@ func (m *Problem) Solve(lines []string) int {
 	for _, code := range codes {
 		//split left and right by hyphen
 		ids := strings.Split(code, "-")
-		left := eulerlib.StrToInt(ids[0])
-		right := eulerlib.StrToInt(ids[1])
+		left := m.Conv().StrToInt(ids[0])
+		right := m.Conv().StrToInt(ids[1])
 		//use left and right as the range of numbers to check
 		for check := left; check <= right; check++ {
 			//sum the matching numbers
@@ -52,10 +62,3 @@ func (m *Problem) Solve(lines []string) int {
 	//day 2, part 2 answer is the sum of all matching numbers
 	return sum
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println(d.GetAnswer() == answer)
-}
@@ -1,4 +1,4 @@
-package main
+package day2part2
 
 import (
 	"testing"
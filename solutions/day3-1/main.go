@@ -1,7 +1,6 @@
-package main
+package day3part1
 
 import (
-	"fmt"
 	"sort"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,6 +8,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   3,
+		Part:  1,
+		Title: "Lobby",
+		Tags:  []string{"greedy", "stack"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -20,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,7 +38,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 func (m *Problem) Solve(lines []string) int {
@@ -41,7 +51,7 @@ func (m *Problem) Solve(lines []string) int {
 		// originally appeared in the string
 		for i := 0; i < len(line); i++ {
 			for j := i + 1; j < len(line); j++ {
-				perms = append(perms, eulerlib.StrToInt(string(line[i])+string(line[j])))
+				perms = append(perms, m.Conv().StrToInt(string(line[i])+string(line[j])))
 			}
 		}
 		// sort perms
@@ -55,10 +65,3 @@ func (m *Problem) Solve(lines []string) int {
 	//day 3, part 1 answer is the sum of all perms
 	return sum
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println(d.GetAnswer() == answer)
-}
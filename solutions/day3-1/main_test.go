@@ -1,4 +1,4 @@
-package main
+package day3part1
 
 import (
 	"testing"
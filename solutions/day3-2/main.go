@@ -1,8 +1,6 @@
-package main
+package day3part2
 
 import (
-	"fmt"
-
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
 )
 
@@ -10,6 +8,16 @@ type Problem struct {
 	eulerlib.Problem
 }
 
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   3,
+		Part:  2,
+		Title: "Lobby",
+		Tags:  []string{"greedy", "stack"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
+}
+
 func (m *Problem) GetProblemName() string {
 	return "Day 3, Part 2"
 }
@@ -19,7 +27,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -27,7 +35,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 /*
@@ -110,10 +118,3 @@ func (m *Problem) Solve(lines []string) int {
 	//day 3, part 2 answer is the sum of all highest-possible perms
 	return sum
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
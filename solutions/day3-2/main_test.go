@@ -1,4 +1,4 @@
-package main
+package day3part2
 
 import (
 	"testing"
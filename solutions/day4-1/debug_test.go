@@ -1,4 +1,4 @@
-package main
+package day4part1
 
 import (
 	"fmt"
@@ -1,7 +1,8 @@
-package main
+package day4part1
 
 import (
-	"fmt"
+	"context"
+	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
 )
@@ -10,16 +11,26 @@ type Problem struct {
 	eulerlib.Problem
 }
 
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   4,
+		Part:  1,
+		Title: "Printing Department",
+		Tags:  []string{"grid"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
+}
+
 func (m *Problem) GetProblemName() string {
 	return "Day 4, Part 1"
 }
 
 func (m *Problem) GetAnswer() string {
-	return "170025781683941"
+	return ""
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.AnswerToStr(m.SolveContext(context.Background(), false))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -27,44 +38,70 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.AnswerToStr(m.SolveContext(context.Background(), true))
+}
+
+// SolveContext solves the full or short input, logging through the Debugger
+// in ctx.
+func (m *Problem) SolveContext(ctx context.Context, short bool) (eulerlib.Answer, error) {
+	filename := "input.txt"
+	if short {
+		filename = "input-test.txt"
+	}
+	lines, err := eulerlib.LoadInput(filename)
+	if err != nil {
+		return eulerlib.Answer{}, err
+	}
+	return m.SolveInput(ctx, lines)
 }
 
 func (m *Problem) Solve(lines []string) int {
-	g := &eulerlib.TGrid{}
-	g.Init()
-	g.ParseTable(lines, false)
-	accessibleRolls := map[string]bool{}
+	count, _ := m.solve(context.Background(), lines)
+	return count
+}
+
+// SolveInput counts the accessible rolls in the given grid.
+func (m *Problem) SolveInput(ctx context.Context, lines []string) (eulerlib.Answer, error) {
+	count, err := m.solve(ctx, lines)
+	if err != nil {
+		return eulerlib.Answer{}, err
+	}
+	return eulerlib.IntAnswer(count), nil
+}
+
+// solve counts the rolls with fewer than 4 adjacent rolls, returning any
+// error in the grid.
+func (m *Problem) solve(ctx context.Context, lines []string) (int, error) {
+	g, err := eulerlib.ParseRuneGrid(lines)
+	if err != nil {
+		return 0, err
+	}
+	accessibleRolls := map[eulerlib.Point]bool{}
 
 	// Check each roll (@) to see if it has fewer than 4 adjacent rolls
-	for y, row := range g.Values {
-		for x, val := range row {
-			if val.(rune) == '@' && g.GetAdjacentCount(x, y, '@') < 4 {
-				accessibleRolls[fmt.Sprintf("%d,%d", x, y)] = true
-			}
+	for p, val := range g.All() {
+		if val == '@' && g.AdjacentCount(p, '@') < 4 {
+			accessibleRolls[p] = true
 		}
 	}
 
 	// Visualization for debugging
-	for y := 0; y < len(g.Values); y++ {
-		for x := 0; x < len(g.Values[0]); x++ {
-			if _, ok := accessibleRolls[fmt.Sprintf("%d,%d", x, y)]; ok {
-				fmt.Print("A") // Accessible roll
-			} else {
-				fmt.Print(string(g.Values[y][x].(rune)))
+	if log := eulerlib.DebuggerFrom(ctx); log.IsDebug() {
+		var sb strings.Builder
+		for y, row := range g.Rows() {
+			for x, val := range row {
+				if _, ok := accessibleRolls[eulerlib.Point{X: x, Y: y}]; ok {
+					sb.WriteRune('A') // Accessible roll
+				} else {
+					sb.WriteRune(val)
+				}
 			}
+			sb.WriteByte('\n')
 		}
-		fmt.Println()
+		log.Debug("accessible rolls", "rolls", len(accessibleRolls), "grid", sb.String())
 	}
 
 	count := len(accessibleRolls)
 	//day 4, part 1 answer is the count of rolls (@) that have fewer than 4 adjacent rolls
-	return count
-}
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
+	return count, nil
 }
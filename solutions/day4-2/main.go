@@ -1,13 +1,24 @@
-package main
+package day4part2
 
 import (
-	"fmt"
+	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
 )
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   4,
+		Part:  2,
+		Title: "Printing Department",
+		Tags:  []string{"grid"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -15,11 +26,11 @@ func (m *Problem) GetProblemName() string {
 }
 
 func (m *Problem) GetAnswer() string {
-	return "170025781683941"
+	return ""
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -27,71 +38,59 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
-func (m *Problem) DisplayDebug(g *eulerlib.TGrid, accessibleRolls map[string]bool) {
+func (m *Problem) DisplayDebug(g *eulerlib.Grid[rune], accessibleRolls map[eulerlib.Point]bool) {
 	// visualization for debugging
-	for y := 0; y < len(g.Values); y++ {
-		for x := 0; x < len(g.Values[0]); x++ {
-			if _, ok := accessibleRolls[fmt.Sprintf("%d,%d", x, y)]; ok {
-				fmt.Print("A") // Accessible roll
+	var sb strings.Builder
+	for y, row := range g.Rows() {
+		for x, val := range row {
+			if _, ok := accessibleRolls[eulerlib.Point{X: x, Y: y}]; ok {
+				sb.WriteRune('A') // Accessible roll
 			} else {
-				fmt.Print(string(g.Values[y][x].(rune)))
+				sb.WriteRune(val)
 			}
 		}
-		fmt.Println()
+		sb.WriteByte('\n')
 	}
-	fmt.Println()
+	m.Logger().Debug("rolls removed", "rolls", len(accessibleRolls), "grid", sb.String())
 }
 
-func getAccessibleRolls(g eulerlib.TGrid) map[string]bool {
-	accessibleRolls := map[string]bool{}
+func getAccessibleRolls(g *eulerlib.Grid[rune]) map[eulerlib.Point]bool {
+	accessibleRolls := map[eulerlib.Point]bool{}
 	// Check each roll (@) to see if it has fewer than 4 adjacent rolls
-	for y, row := range g.Values {
-		for x, val := range row {
-			if val.(rune) == '@' && g.GetAdjacentCount(x, y, '@') < 4 {
-				accessibleRolls[fmt.Sprintf("%d,%d", x, y)] = true
-			}
+	for p, val := range g.All() {
+		if val == '@' && g.AdjacentCount(p, '@') < 4 {
+			accessibleRolls[p] = true
 		}
 	}
 	return accessibleRolls
 }
 
-func removeRolls(g *eulerlib.TGrid, accessibleRolls map[string]bool) int {
+func removeRolls(g *eulerlib.Grid[rune], accessibleRolls map[eulerlib.Point]bool) int {
 	count := 0
-	for y, row := range g.Values {
-		for x := range row {
-			if _, ok := accessibleRolls[fmt.Sprintf("%d,%d", x, y)]; ok {
-				g.Values[y][x] = '.'
-				count++
-			}
+	for p := range g.All() {
+		if _, ok := accessibleRolls[p]; ok {
+			g.SetAt(p, '.')
+			count++
 		}
 	}
 	return count
 }
 
 func (m *Problem) Solve(lines []string) int {
-	g := &eulerlib.TGrid{}
-	g.Init()
-	g.ParseTable(lines, false)
+	g := eulerlib.MustParseRuneGrid(lines)
 	countRemovedRolls := 0
 	for {
-		accessibleRolls := getAccessibleRolls(*g)
+		accessibleRolls := getAccessibleRolls(g)
 		if len(accessibleRolls) == 0 {
 			break
 		}
 		countRemovedRolls += removeRolls(g, accessibleRolls)
-		if eulerlib.GetDebugger().IsDebug() {
+		if m.Logger().IsDebug() {
 			m.DisplayDebug(g, accessibleRolls)
 		}
 	}
 	return countRemovedRolls
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
@@ -1,4 +1,4 @@
-package main
+package day4part2
 
 import (
 	"testing"
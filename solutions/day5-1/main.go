@@ -1,7 +1,6 @@
-package main
+package day5part1
 
 import (
-	"fmt"
 	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,6 +8,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   5,
+		Part:  1,
+		Title: "Cafeteria",
+		Tags:  []string{"ranges"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -20,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,7 +38,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 func (m *Problem) parseData(lines []string) ([][]int, []int) {
@@ -41,11 +51,11 @@ func (m *Problem) parseData(lines []string) ([][]int, []int) {
 
 		} else if !seenBlankLine {
 			r := strings.Split(line, "-")
-			lower := eulerlib.StrToInt(r[0])
-			upper := eulerlib.StrToInt(r[1])
+			lower := m.Conv().StrToInt(r[0])
+			upper := m.Conv().StrToInt(r[1])
 			freshRanges = append(freshRanges, []int{lower, upper})
 		} else {
-			ingredientList = append(ingredientList, eulerlib.StrToInt(line))
+			ingredientList = append(ingredientList, m.Conv().StrToInt(line))
 		}
 	}
 	return freshRanges, ingredientList
@@ -53,7 +63,7 @@ func (m *Problem) parseData(lines []string) ([][]int, []int) {
 
 func (m *Problem) Solve(lines []string) int {
 	freshRanges, ingredientList := m.parseData(lines)
-	fmt.Println("parsed, now processing")
+	m.Logger().Info("parsed, now processing", "ranges", len(freshRanges), "ingredients", len(ingredientList))
 	countFresh := 0
 	for _, i := range ingredientList {
 		for _, j := range freshRanges {
@@ -65,10 +75,3 @@ func (m *Problem) Solve(lines []string) int {
 	}
 	return countFresh
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
@@ -1,4 +1,4 @@
-package main
+package day5part1
 
 import (
 	"testing"
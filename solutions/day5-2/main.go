@@ -1,7 +1,6 @@
-package main
+package day5part2
 
 import (
-	"fmt"
 	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,6 +8,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   5,
+		Part:  2,
+		Title: "Cafeteria",
+		Tags:  []string{"ranges"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -20,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,7 +38,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 func (m *Problem) parseData(lines []string) *eulerlib.TRanges {
@@ -38,8 +48,8 @@ func (m *Problem) parseData(lines []string) *eulerlib.TRanges {
 			break
 		}
 		r := strings.Split(line, "-")
-		lower := eulerlib.StrToInt(r[0])
-		upper := eulerlib.StrToInt(r[1])
+		lower := m.Conv().StrToInt(r[0])
+		upper := m.Conv().StrToInt(r[1])
 		freshRanges = append(freshRanges, eulerlib.TRange{Lower: lower, Upper: upper})
 
 	}
@@ -52,10 +62,3 @@ func (m *Problem) Solve(lines []string) int {
 	freshRanges = freshRanges.RemoveContainedRanges()
 	return freshRanges.CountAll()
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
@@ -1,7 +1,6 @@
-package main
+package day6part1
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -12,6 +11,16 @@ type Problem struct {
 	eulerlib.Problem
 }
 
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   6,
+		Part:  1,
+		Title: "Trash Compactor",
+		Tags:  []string{"parsing"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
+}
+
 func (m *Problem) GetProblemName() string {
 	return "Day 6, Part 1"
 }
@@ -21,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -29,7 +38,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 var operatorMap = map[string]int{
@@ -107,10 +116,3 @@ func (m *Problem) Solve(lines []string) int {
 	}
 	return sum
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
@@ -1,7 +1,6 @@
-package main
+package day6part2
 
 import (
-	"fmt"
 	"strconv"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,6 +8,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   6,
+		Part:  2,
+		Title: "Trash Compactor",
+		Tags:  []string{"parsing"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -20,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,7 +38,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 var operatorMap = map[string]int{
@@ -121,16 +131,16 @@ func (m *Problem) Solve(lines []string) int {
 		case 0:
 			problemTotal = 0
 		case 1:
-			problemTotal = eulerlib.StrToInt(words[i][0])
+			problemTotal = m.Conv().StrToInt(words[i][0])
 		case 2:
 			problemTotal = 1
 		case 3:
-			problemTotal = eulerlib.StrToInt(words[i][0])
+			problemTotal = m.Conv().StrToInt(words[i][0])
 		default:
 		}
 
 		for _, p := range problem {
-			val := eulerlib.StrToInt(p)
+			val := m.Conv().StrToInt(p)
 			switch operator {
 			case 0:
 				problemTotal += val
@@ -154,10 +164,3 @@ func (m *Problem) Solve(lines []string) int {
 	}
 	return sum
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
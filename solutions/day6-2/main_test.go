@@ -1,4 +1,4 @@
-package main
+package day6part2
 
 import (
 	"testing"
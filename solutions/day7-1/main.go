@@ -1,15 +1,24 @@
-package main
+package day7part1
 
 import (
-	"fmt"
+	"iter"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
 )
 
 type Problem struct {
 	eulerlib.Problem
-	grid     eulerlib.TGrid
-	posCache map[string]bool
+	grid *eulerlib.Grid[rune]
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   7,
+		Part:  1,
+		Title: "Laboratories",
+		Tags:  []string{"grid", "search"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -21,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -29,41 +38,40 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
-func (m *Problem) GetBeams(pos *eulerlib.TGridPosition) {
-	var err error = nil
-	for err == nil {
-		pos, err = m.grid.WalkFromWithBlocker(pos, '^')
-	}
-	if err.Error() == "blocked" {
-		if m.posCache[fmt.Sprintf("%d,%d", pos.X, pos.Y)] {
+// beamMoves returns the cells a beam in cell p lights next: the cell below,
+// or the cells either side of a splitter below.
+func (m *Problem) beamMoves(p eulerlib.Point) iter.Seq[eulerlib.Point] {
+	return func(yield func(eulerlib.Point) bool) {
+		below := p.Move(eulerlib.DownDirection)
+		v, ok := m.grid.GetAt(below)
+		if !ok {
+			return
+		}
+		if v != '^' {
+			yield(below)
 			return
 		}
-		m.posCache[fmt.Sprintf("%d,%d", pos.X, pos.Y)] = true
-		//fmt.Println("blocked at", pos.X, pos.Y)
-		left := &eulerlib.TGridPosition{X: pos.X - 1, Y: pos.Y + 1, Direction: eulerlib.DownDirection}
-		right := &eulerlib.TGridPosition{X: pos.X + 1, Y: pos.Y + 1, Direction: eulerlib.DownDirection}
-		m.GetBeams(left)
-		m.GetBeams(right)
+		for _, side := range []eulerlib.TDirection{eulerlib.LeftDirection, eulerlib.RightDirection} {
+			if next := below.Move(side); m.grid.Contains(next) && !yield(next) {
+				return
+			}
+		}
 	}
 }
 
 func (m *Problem) Solve(lines []string) int {
-	m.grid = eulerlib.TGrid{}
-	m.grid.Init()
-	m.grid.ParseTable(lines, false)
-	m.posCache = make(map[string]bool)
-	startX, startY := m.grid.FindElement('S')
-	start := &eulerlib.TGridPosition{X: startX, Y: startY, Direction: eulerlib.DownDirection}
-	m.GetBeams(start)
-	return len(m.posCache)
-}
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
+	m.grid = eulerlib.MustParseRuneGrid(lines)
+	start, _ := m.grid.Find('S')
+	beams := eulerlib.BFS([]eulerlib.Point{start}, m.beamMoves, nil)
+	//count the splitters some beam runs into
+	split := 0
+	for p := range beams.Dist {
+		if v, _ := m.grid.GetAt(p.Move(eulerlib.DownDirection)); v == '^' {
+			split++
+		}
+	}
+	return split
 }
@@ -1,4 +1,4 @@
-package main
+package day7part1
 
 import (
 	"testing"
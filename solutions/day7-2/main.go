@@ -1,4 +1,4 @@
-package main
+package day7part2
 
 import (
 	"fmt"
@@ -12,6 +12,16 @@ type Problem struct {
 	countCache map[string]int
 }
 
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   7,
+		Part:  2,
+		Title: "Laboratories",
+		Tags:  []string{"grid", "memoisation"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
+}
+
 func (m *Problem) GetProblemName() string {
 	return "Day 7, Part 2"
 }
@@ -21,7 +31,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -29,7 +39,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 func (m *Problem) GetCacheKey(pos *eulerlib.TGridPosition) string {
@@ -73,10 +83,3 @@ func (m *Problem) Solve(lines []string) int {
 	start := &eulerlib.TGridPosition{X: startX, Y: startY, Direction: eulerlib.DownDirection}
 	return m.CountBeams(start)
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
@@ -1,7 +1,6 @@
-package main
+package day8part1
 
 import (
-	"fmt"
 	"slices"
 	"strings"
 
@@ -10,6 +9,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   8,
+		Part:  1,
+		Title: "Playground",
+		Tags:  []string{"3d", "union-find"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -21,7 +31,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt"), 1000))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt"), 1000))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -29,7 +39,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt"), 10))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt"), 10))
 }
 
 func (m *Problem) parseBoxes(lines []string) eulerlib.ThreedCoords {
@@ -37,9 +47,9 @@ func (m *Problem) parseBoxes(lines []string) eulerlib.ThreedCoords {
 	for _, line := range lines {
 		box := eulerlib.ThreedCoord{}
 		vals := strings.Split(line, ",")
-		box.X = eulerlib.StrToInt(vals[0])
-		box.Y = eulerlib.StrToInt(vals[1])
-		box.Z = eulerlib.StrToInt(vals[2])
+		box.X = m.Conv().StrToInt(vals[0])
+		box.Y = m.Conv().StrToInt(vals[1])
+		box.Z = m.Conv().StrToInt(vals[2])
 		boxes = append(boxes, box)
 	}
 	return boxes
@@ -112,26 +122,19 @@ func (m *Problem) Solve(lines []string, numIterations int) int {
 	if len(distances) > numIterations {
 		distances = distances[:numIterations]
 	}
-	fmt.Println("we have", len(distances), "distances")
+	m.Logger().Info("distances sorted", "distances", len(distances))
 	circuits := m.getCircuits(distances)
 
 	circuits = *circuits.SortByLengthDesc()
 	counter := 1
 	if len(circuits) < 3 {
-		fmt.Println("Not enough circuits!")
+		m.Logger().Info("not enough circuits", "circuits", len(circuits))
 		return 0
 	}
-	fmt.Println("have circuits:", len(circuits))
+	m.Logger().Info("circuits found", "circuits", len(circuits))
 	for _, c := range circuits[0:3] {
 		counter = counter * c.GetLength()
 	}
 	return counter
 
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
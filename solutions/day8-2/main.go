@@ -1,7 +1,6 @@
-package main
+package day8part2
 
 import (
-	"fmt"
 	"slices"
 	"strings"
 
@@ -10,6 +9,17 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   8,
+		Part:  2,
+		Title: "Playground",
+		Tags:  []string{"3d", "union-find"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
@@ -21,7 +31,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt"), 1000))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt"), 1000))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -29,7 +39,7 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt"), 10))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt"), 10))
 }
 
 func (m *Problem) parseBoxes(lines []string) eulerlib.ThreedCoords {
@@ -37,9 +47,9 @@ func (m *Problem) parseBoxes(lines []string) eulerlib.ThreedCoords {
 	for _, line := range lines {
 		box := eulerlib.ThreedCoord{}
 		vals := strings.Split(line, ",")
-		box.X = eulerlib.StrToInt(vals[0])
-		box.Y = eulerlib.StrToInt(vals[1])
-		box.Z = eulerlib.StrToInt(vals[2])
+		box.X = m.Conv().StrToInt(vals[0])
+		box.Y = m.Conv().StrToInt(vals[1])
+		box.Z = m.Conv().StrToInt(vals[2])
 		boxes = append(boxes, box)
 	}
 	return boxes
@@ -103,7 +113,7 @@ func (m *Problem) getCircuitsUntilSingleCircuit(distances eulerlib.ThreedDistanc
 		// the total box matters, we are only keeping track of connected boxes whereas
 		// the language of the puzzle talks about all boxes being in a single circuit
 		if len(circuits) == 1 && circuits[0].GetLength() == totalBoxes && iterations >= minIterations {
-			fmt.Println("found on iteration", iterations, d.From, d.To, "circuit length:", circuits[0].GetLength())
+			m.Logger().Info("single circuit found", "iteration", iterations, "from", *d.From, "to", *d.To, "length", circuits[0].GetLength())
 			return d
 		}
 	}
@@ -114,19 +124,10 @@ func (m *Problem) Solve(lines []string, numIterations int) int {
 	boxes := m.parseBoxes(lines)
 	distances := boxes.GetUniqueDistances()
 	distances = *distances.SortByDistance()
-	fmt.Println("we have", len(distances), "distances")
-	fmt.Println("we have", len(boxes), "boxes")
+	m.Logger().Info("distances sorted", "distances", len(distances), "boxes", len(boxes))
 
 	lastDistance := m.getCircuitsUntilSingleCircuit(distances, numIterations, len(boxes))
-	fmt.Println("last distance from:", lastDistance.From)
-	fmt.Println("last distance to:", lastDistance.To)
+	m.Logger().Info("last distance", "from", *lastDistance.From, "to", *lastDistance.To)
 
 	return lastDistance.From.X * lastDistance.To.X
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
@@ -1,4 +1,4 @@
-package main
+package day8part2
 
 import (
 	"testing"
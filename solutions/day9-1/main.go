@@ -1,7 +1,6 @@
-package main
+package day9part1
 
 import (
-	"fmt"
 	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,10 +8,21 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   9,
+		Part:  1,
+		Title: "Movie Theater",
+		Tags:  []string{"geometry", "grid"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
-	return "Day 8, Part 2"
+	return "Day 9, Part 1"
 }
 
 func (m *Problem) GetAnswer() string {
@@ -20,7 +30,7 @@ func (m *Problem) GetAnswer() string {
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input.txt")))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,19 +38,14 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
-}
-
-type TPoint struct {
-	X int
-	Y int
+	return eulerlib.IntToStr(m.Solve(eulerlib.MustLoadInput("input-test.txt")))
 }
 
 func (m *Problem) Solve(lines []string) int {
-	tiles := []TPoint{}
+	tiles := []eulerlib.Point{}
 	for _, line := range lines {
 		coords := strings.Split(line, ",")
-		tiles = append(tiles, TPoint{X: eulerlib.StrToInt(coords[0]), Y: eulerlib.StrToInt(coords[1])})
+		tiles = append(tiles, eulerlib.Point{X: m.Conv().StrToInt(coords[0]), Y: m.Conv().StrToInt(coords[1])})
 	}
 	max := 0
 	for i, t1 := range tiles {
@@ -55,10 +60,3 @@ func (m *Problem) Solve(lines []string) int {
 	}
 	return max
 }
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
-}
@@ -1,4 +1,4 @@
-package main
+package day9part1
 
 import (
 	"testing"
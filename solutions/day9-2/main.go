@@ -1,7 +1,7 @@
-package main
+package day9part2
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
 	eulerlib "github.com/nfitbh72/aoc2025/solutions/lib"
@@ -9,18 +9,29 @@ import (
 
 type Problem struct {
 	eulerlib.Problem
+	eulerlib.Logging
+}
+
+func init() {
+	eulerlib.Register(eulerlib.ProblemInfo{
+		Day:   9,
+		Part:  2,
+		Title: "Movie Theater",
+		Tags:  []string{"geometry", "grid"},
+		New:   func() eulerlib.Problem { return &Problem{} },
+	})
 }
 
 func (m *Problem) GetProblemName() string {
-	return "Day 8, Part 2"
+	return "Day 9, Part 2"
 }
 
 func (m *Problem) GetAnswer() string {
-	return "4725826296"
+	return ""
 }
 
 func (m *Problem) GenerateAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input.txt")))
+	return eulerlib.AnswerToStr(m.SolveContext(context.Background(), false))
 }
 
 func (m *Problem) GetShortAnswer() string {
@@ -28,15 +39,28 @@ func (m *Problem) GetShortAnswer() string {
 }
 
 func (m *Problem) GenerateShortAnswer() string {
-	return eulerlib.IntToStr(m.Solve(eulerlib.GetFileInputTxt("input-test.txt")))
+	return eulerlib.AnswerToStr(m.SolveContext(context.Background(), true))
 }
 
-type TPoint struct {
-	X int
-	Y int
+// SolveContext solves the full or short input, giving up between rectangles
+// once ctx is cancelled.
+func (m *Problem) SolveContext(ctx context.Context, short bool) (eulerlib.Answer, error) {
+	filename := "input.txt"
+	if short {
+		filename = "input-test.txt"
+	}
+	lines, err := eulerlib.LoadInput(filename)
+	if err != nil {
+		return eulerlib.Answer{}, err
+	}
+	max, err := m.solve(ctx, lines)
+	if err != nil {
+		return eulerlib.Answer{}, err
+	}
+	return eulerlib.IntAnswer(max), nil
 }
 
-func (m *Problem) IsRectangleEnclosed(grid *eulerlib.CompactGrid, p1, p2 TPoint) bool {
+func (m *Problem) IsRectangleEnclosed(grid *eulerlib.CompactGrid, p1, p2 eulerlib.Point) bool {
 	minX, maxX := p1.X, p2.X
 	if p1.X > p2.X {
 		minX, maxX = p2.X, p1.X
@@ -72,10 +96,17 @@ func (m *Problem) IsRectangleEnclosed(grid *eulerlib.CompactGrid, p1, p2 TPoint)
 }
 
 func (m *Problem) Solve(lines []string) int {
-	redTiles := []TPoint{}
+	max, _ := m.solve(context.Background(), lines)
+	return max
+}
+
+// solve finds the largest enclosed rectangle, returning ctx's error if it is
+// cancelled first.
+func (m *Problem) solve(ctx context.Context, lines []string) (int, error) {
+	redTiles := []eulerlib.Point{}
 	for _, line := range lines {
 		coords := strings.Split(line, ",")
-		redTiles = append(redTiles, TPoint{X: eulerlib.StrToInt(coords[0]), Y: eulerlib.StrToInt(coords[1])})
+		redTiles = append(redTiles, eulerlib.Point{X: m.Conv().StrToInt(coords[0]), Y: m.Conv().StrToInt(coords[1])})
 	}
 
 	maxX := 0
@@ -88,11 +119,11 @@ func (m *Problem) Solve(lines []string) int {
 			maxY = redTile.Y
 		}
 	}
-	fmt.Println("grid size", maxX+1, maxY+1)
+	log := m.Logger()
+	log.Info("drawing lines", "width", maxX+1, "height", maxY+1)
 	grid := eulerlib.NewCompactGrid(maxX+1, maxY+1)
-	fmt.Println("drawing lines")
 	for i, redTile := range redTiles {
-		grid.Set(redTile.X, redTile.Y, 1) // 1 = 'R'
+		grid.SetAt(redTile, 1) // 1 = 'R'
 		nextRed := redTiles[(i+1)%len(redTiles)]
 		if nextRed.X-redTile.X != 0 {
 			start := redTile.X + 1
@@ -117,41 +148,39 @@ func (m *Problem) Solve(lines []string) int {
 		}
 	}
 
-	if eulerlib.GetDebugger().IsDebug() {
-		fmt.Println(grid.ToString(0, maxX, 0, maxY))
+	if log.IsDebug() {
+		log.Debug("boundary drawn", "grid", grid.ToString(0, maxX, 0, maxY))
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
 	}
-	fmt.Println("filling enclosed area")
+	log.Info("filling enclosed area")
 	count := grid.FillEnclosedArea(0, maxX, 0, maxY, 2, map[byte]bool{1: true, 2: true}) // 1='R', 2='G'
-	fmt.Println("filled", count, "spaces")
+	log.Info("filled enclosed area", "spaces", count)
 
-	if eulerlib.GetDebugger().IsDebug() {
-		fmt.Println(grid.ToString(0, maxX, 0, maxY))
+	if log.IsDebug() {
+		log.Debug("area filled", "grid", grid.ToString(0, maxX, 0, maxY))
 	}
 
-	fmt.Println("checking rectangles")
+	log.Info("checking rectangles")
+	pairs := int64(len(redTiles) * (len(redTiles) - 1) / 2)
+	progress := m.Progress().Task("rectangles", pairs)
+	defer progress.Done()
 	max := 0
-	count = 0
 	for i, t1 := range redTiles {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		for j := i + 1; j < len(redTiles); j++ {
 			t2 := redTiles[j]
-			count++
 			if m.IsRectangleEnclosed(grid, t1, t2) {
 				area := (eulerlib.IntAbs(t1.X-t2.X) + 1) * (eulerlib.IntAbs(t1.Y-t2.Y) + 1)
 				if area > max {
 					max = area
 				}
 			}
-			if count%100000 == 0 {
-				fmt.Println(count)
-			}
 		}
+		progress.Add(int64(len(redTiles) - i - 1))
 	}
-	return max
-}
-
-func main() {
-	d := Problem{}
-	answer := d.GenerateAnswer()
-	fmt.Println(answer)
-	fmt.Println("does it match?", d.GetAnswer() == answer)
+	return max, nil
 }
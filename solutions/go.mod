@@ -5,6 +5,7 @@ go 1.24.5
 require github.com/nfitbh72/aoc2025/lib v0.0.0-20251202002136-172f3b8454ac
 
 require (
+	github.com/google/pprof v0.0.0-20260115054156-294ebfa9ad83 // indirect
 	golang.org/x/exp v0.0.0-20251125195548-87e1e737ad39 // indirect
 	gonum.org/v1/gonum v0.16.0 // indirect
 )
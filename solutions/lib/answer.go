@@ -0,0 +1,240 @@
+package eulerlib
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AnswerKind identifies which representation an Answer holds.
+type AnswerKind int
+
+const (
+	// AnswerEmpty is the zero Answer, used when no answer is known.
+	AnswerEmpty AnswerKind = iota
+	// AnswerInt holds an integer that fits in an int64.
+	AnswerInt
+	// AnswerBig holds an integer too large for an int64.
+	AnswerBig
+	// AnswerString holds a single line of text.
+	AnswerString
+	// AnswerLines holds multi-line output, such as letters drawn on a grid.
+	AnswerLines
+)
+
+// Answer is a puzzle answer with a canonical string form. Integer answers
+// compare numerically regardless of whether they were built from an int,
+// int64, *big.Int or a string, so "0042" style formatting or overflow cannot
+// produce a false mismatch.
+type Answer struct {
+	kind  AnswerKind
+	i     int64
+	big   *big.Int
+	s     string
+	lines []string
+}
+
+// integerRe matches canonical decimal integers: no leading zeros or plus sign.
+var integerRe = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+
+// IntAnswer returns an integer Answer.
+func IntAnswer(v int) Answer {
+	return Answer{kind: AnswerInt, i: int64(v)}
+}
+
+// Int64Answer returns an integer Answer from an int64.
+func Int64Answer(v int64) Answer {
+	return Answer{kind: AnswerInt, i: v}
+}
+
+// BigAnswer returns an integer Answer from a big.Int. Values that fit in an
+// int64 are stored as AnswerInt so they compare equal to IntAnswer.
+func BigAnswer(v *big.Int) Answer {
+	if v == nil {
+		return Answer{}
+	}
+	if v.IsInt64() {
+		return Int64Answer(v.Int64())
+	}
+	return Answer{kind: AnswerBig, big: new(big.Int).Set(v)}
+}
+
+// StringAnswer returns a text Answer. Surrounding whitespace is trimmed.
+func StringAnswer(s string) Answer {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Answer{}
+	}
+	return Answer{kind: AnswerString, s: s}
+}
+
+// LinesAnswer returns a multi-line Answer. Trailing spaces on each line and
+// blank lines before or after the output are dropped; a single remaining line
+// becomes a StringAnswer.
+func LinesAnswer(lines []string) Answer {
+	trimmed := make([]string, len(lines))
+	for i, line := range lines {
+		trimmed[i] = strings.TrimRight(line, " \t\r")
+	}
+	for len(trimmed) > 0 && trimmed[0] == "" {
+		trimmed = trimmed[1:]
+	}
+	for len(trimmed) > 0 && trimmed[len(trimmed)-1] == "" {
+		trimmed = trimmed[:len(trimmed)-1]
+	}
+	switch len(trimmed) {
+	case 0:
+		return Answer{}
+	case 1:
+		return StringAnswer(trimmed[0])
+	}
+	return Answer{kind: AnswerLines, lines: trimmed}
+}
+
+// ParseAnswer converts the string form used by Problem.GetAnswer and
+// Problem.GenerateAnswer into an Answer, recognising integers of any size and
+// multi-line output.
+func ParseAnswer(s string) Answer {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	if strings.Contains(s, "\n") {
+		return LinesAnswer(strings.Split(s, "\n"))
+	}
+	if integerRe.MatchString(s) {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return Int64Answer(i)
+		}
+		b, _ := new(big.Int).SetString(s, 10)
+		return BigAnswer(b)
+	}
+	return StringAnswer(s)
+}
+
+// Kind reports which representation the Answer holds.
+func (a Answer) Kind() AnswerKind {
+	return a.kind
+}
+
+// IsEmpty reports whether the Answer holds no value.
+func (a Answer) IsEmpty() bool {
+	return a.kind == AnswerEmpty
+}
+
+// IsInteger reports whether the Answer holds an integer of any size.
+func (a Answer) IsInteger() bool {
+	return a.kind == AnswerInt || a.kind == AnswerBig
+}
+
+// Int64 returns the Answer as an int64 and whether it fits.
+func (a Answer) Int64() (int64, bool) {
+	return a.i, a.kind == AnswerInt
+}
+
+// BigInt returns integer Answers as a new big.Int, or nil for other kinds.
+func (a Answer) BigInt() *big.Int {
+	switch a.kind {
+	case AnswerInt:
+		return big.NewInt(a.i)
+	case AnswerBig:
+		return new(big.Int).Set(a.big)
+	}
+	return nil
+}
+
+// Lines returns the Answer split into lines.
+func (a Answer) Lines() []string {
+	if a.kind == AnswerLines {
+		return append([]string{}, a.lines...)
+	}
+	if a.kind == AnswerEmpty {
+		return []string{}
+	}
+	return []string{a.String()}
+}
+
+// String returns the canonical text of the Answer: base-10 integers, trimmed
+// text, or lines joined with '\n'. The empty Answer formats as "".
+func (a Answer) String() string {
+	switch a.kind {
+	case AnswerInt:
+		return strconv.FormatInt(a.i, 10)
+	case AnswerBig:
+		return a.big.String()
+	case AnswerString:
+		return a.s
+	case AnswerLines:
+		return strings.Join(a.lines, "\n")
+	}
+	return ""
+}
+
+// Equal reports whether two Answers are the same value. Integers compare
+// numerically and everything else compares by canonical text.
+func (a Answer) Equal(b Answer) bool {
+	if a.IsInteger() && b.IsInteger() {
+		if a.kind == AnswerInt && b.kind == AnswerInt {
+			return a.i == b.i
+		}
+		return a.BigInt().Cmp(b.BigInt()) == 0
+	}
+	return a.kind == b.kind && a.String() == b.String()
+}
+
+// MarshalText encodes the Answer using its canonical string form.
+func (a Answer) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes an Answer previously encoded with MarshalText.
+func (a *Answer) UnmarshalText(text []byte) error {
+	*a = ParseAnswer(string(text))
+	return nil
+}
+
+// AnswerProblem is an optional extension of Problem for solutions that return
+// structured answers and report failures as errors instead of panicking.
+// Expected answers still come from GetAnswer and GetShortAnswer, which are
+// compared through ParseAnswer.
+type AnswerProblem interface {
+	Problem
+	Answer() (Answer, error)
+	ShortAnswer() (Answer, error)
+}
+
+// stringProblem adapts a string-only Problem to AnswerProblem.
+type stringProblem struct {
+	Problem
+}
+
+// Answer parses the string returned by GenerateAnswer.
+func (m stringProblem) Answer() (Answer, error) {
+	return ParseAnswer(m.GenerateAnswer()), nil
+}
+
+// ShortAnswer parses the string returned by GenerateShortAnswer.
+func (m stringProblem) ShortAnswer() (Answer, error) {
+	return ParseAnswer(m.GenerateShortAnswer()), nil
+}
+
+// AsAnswerProblem returns problem as an AnswerProblem, wrapping string-only
+// implementations so that their generated answers are parsed with
+// ParseAnswer.
+func AsAnswerProblem(problem Problem) AnswerProblem {
+	if ap, ok := problem.(AnswerProblem); ok {
+		return ap
+	}
+	return stringProblem{Problem: problem}
+}
+
+// AnswerToStr formats the result of an AnswerProblem method for the string
+// based Problem interface, panicking if err is non-nil. It lets an
+// AnswerProblem implement GenerateAnswer as
+//
+//	return eulerlib.AnswerToStr(m.Answer())
+func AnswerToStr(a Answer, err error) string {
+	if err != nil {
+		panic(fmt.Sprintf("generating answer: %v", err))
+	}
+	return a.String()
+}
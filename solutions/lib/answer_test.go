@@ -0,0 +1,146 @@
+package eulerlib
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParseAnswer(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tests := []TTest{
+		{Name: "int", Input: "6099", Expect: IntAnswer(6099)},
+		{Name: "padded int", Input: " 42\n", Expect: IntAnswer(42)},
+		{Name: "negative", Input: "-17", Expect: IntAnswer(-17)},
+		{Name: "13 digits", Input: "4405895212738", Expect: Int64Answer(4405895212738)},
+		{Name: "big", Input: "123456789012345678901234567890", Expect: BigAnswer(huge)},
+		{Name: "leading zero stays text", Input: "007", Expect: StringAnswer("007")},
+		{Name: "text", Input: "abc,def", Expect: StringAnswer("abc,def")},
+		{Name: "lines", Input: "#..#\r\n####  \n\n", Expect: LinesAnswer([]string{"#..#", "####"})},
+		{Name: "empty", Input: "  ", Expect: Answer{}},
+	}
+	for _, test := range tests {
+		output := ParseAnswer(test.Input.(string))
+		if !output.Equal(test.Expect.(Answer)) || output.Kind() != test.Expect.(Answer).Kind() {
+			ReportError(t, "answer.ParseAnswer", test, output)
+		}
+	}
+}
+
+func TestAnswerString(t *testing.T) {
+	huge, _ := new(big.Int).SetString("-98765432109876543210", 10)
+	tests := []TTest{
+		{Name: "int", Input: IntAnswer(3), Expect: "3"},
+		{Name: "int64", Input: Int64Answer(170025781683941), Expect: "170025781683941"},
+		{Name: "big", Input: BigAnswer(huge), Expect: "-98765432109876543210"},
+		{Name: "string", Input: StringAnswer(" hi "), Expect: "hi"},
+		{Name: "lines", Input: LinesAnswer([]string{"", "ab ", "cd", ""}), Expect: "ab\ncd"},
+		{Name: "single line", Input: LinesAnswer([]string{"ab"}), Expect: "ab"},
+		{Name: "empty", Input: Answer{}, Expect: ""},
+	}
+	for _, test := range tests {
+		CheckTest(t, "answer.String", test, test.Input.(Answer).String())
+	}
+}
+
+func TestAnswerEqual(t *testing.T) {
+	tests := []TTest{
+		{Name: "int vs int64", Input: []Answer{IntAnswer(5), Int64Answer(5)}, Expect: true},
+		{Name: "small big vs int", Input: []Answer{BigAnswer(big.NewInt(99)), IntAnswer(99)}, Expect: true},
+		{Name: "int vs parsed", Input: []Answer{IntAnswer(1227775554), ParseAnswer("1227775554")}, Expect: true},
+		{Name: "different ints", Input: []Answer{IntAnswer(1), IntAnswer(2)}, Expect: false},
+		{Name: "int vs text", Input: []Answer{IntAnswer(7), StringAnswer("007")}, Expect: false},
+		{Name: "text", Input: []Answer{StringAnswer("x"), ParseAnswer("x\n")}, Expect: true},
+		{Name: "empty vs zero", Input: []Answer{Answer{}, IntAnswer(0)}, Expect: false},
+		{Name: "empty vs empty", Input: []Answer{Answer{}, ParseAnswer("")}, Expect: true},
+	}
+	for _, test := range tests {
+		pair := test.Input.([]Answer)
+		CheckTest(t, "answer.Equal", test, pair[0].Equal(pair[1]))
+	}
+}
+
+func TestAnswerAccessors(t *testing.T) {
+	i, ok := IntAnswer(12).Int64()
+	CheckTest(t, "answer.Int64", TTest{Name: "int", Expect: true}, ok && i == 12)
+	_, ok = StringAnswer("x").Int64()
+	CheckTest(t, "answer.Int64", TTest{Name: "string", Expect: false}, ok)
+	CheckTest(t, "answer.BigInt", TTest{Name: "int", Expect: big.NewInt(12)}, IntAnswer(12).BigInt())
+	CheckTest(t, "answer.Lines", TTest{Name: "int", Expect: []string{"12"}}, IntAnswer(12).Lines())
+	CheckTest(t, "answer.Lines", TTest{Name: "empty", Expect: []string{}}, Answer{}.Lines())
+}
+
+func TestAnswerJSON(t *testing.T) {
+	type record struct {
+		Answer Answer `json:"answer"`
+	}
+	b, err := json.Marshal(record{Answer: IntAnswer(4405895212738)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "answer.MarshalText", TTest{Name: "json", Expect: `{"answer":"4405895212738"}`}, string(b))
+	var decoded record
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "answer.UnmarshalText", TTest{Name: "json", Expect: true}, decoded.Answer.Equal(IntAnswer(4405895212738)))
+}
+
+// TAnswerProblem implements AnswerProblem directly.
+type TAnswerProblem struct {
+	Problem
+	err error
+}
+
+func (m *TAnswerProblem) GetProblemName() string { return "Answer Problem" }
+
+func (m *TAnswerProblem) GetAnswer() string { return "10000000000000000000000" }
+
+func (m *TAnswerProblem) Answer() (Answer, error) {
+	b, _ := new(big.Int).SetString("10000000000000000000000", 10)
+	return BigAnswer(b), m.err
+}
+
+func (m *TAnswerProblem) ShortAnswer() (Answer, error) {
+	return Answer{}, errors.New("no short input")
+}
+
+func (m *TAnswerProblem) GenerateAnswer() string { return AnswerToStr(m.Answer()) }
+
+func TestAsAnswerProblem(t *testing.T) {
+	direct := &TAnswerProblem{}
+	if AsAnswerProblem(direct) != AnswerProblem(direct) {
+		t.Error("expected an AnswerProblem to be returned unchanged")
+	}
+	answer, err := AsAnswerProblem(&TTestProblem{}).Answer()
+	if err != nil || !answer.Equal(IntAnswer(1234)) {
+		t.Errorf("expected adapted answer 1234, got %v (%v)", answer, err)
+	}
+}
+
+func TestAnswerProblemWithTestProblem(t *testing.T) {
+	TestProblem(&TAnswerProblem{}, t)
+
+	var fake testing.T
+	TestProblem(&TAnswerProblem{err: errors.New("boom")}, &fake)
+	if !fake.Failed() {
+		t.Error("expected TestProblem to fail when Answer returns an error")
+	}
+}
+
+func TestAnswerToStrPanicsOnError(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected AnswerToStr to panic on error")
+		}
+	}()
+	AnswerToStr((&TAnswerProblem{err: errors.New("boom")}).Answer())
+}
+
+func TestRunProblemRecordsAnswerError(t *testing.T) {
+	result := RunProblem(&TAnswerProblem{}, true)
+	CheckTest(t, "runner.RunProblem", TTest{Name: "status", Expect: "ERROR"}, result.Status())
+	result = RunProblem(&TAnswerProblem{}, false)
+	CheckTest(t, "runner.RunProblem", TTest{Name: "status", Expect: "pass"}, result.Status())
+}
@@ -0,0 +1,212 @@
+package eulerlib
+
+import (
+	"context"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// BenchOptions controls how Bench measures a problem.
+type BenchOptions struct {
+	// Runs is the number of times the problem is solved; at least one.
+	Runs int
+	// Short benchmarks the sample input instead of the full input.
+	Short bool
+	// Timeout bounds each run; zero or less means no limit.
+	Timeout time.Duration
+	// SampleEvery is how often the heap is sampled for PeakHeap. Sampling
+	// stops the world briefly, so very small intervals distort the timings.
+	// It defaults to 5ms.
+	SampleEvery time.Duration
+}
+
+// BenchResult summarises repeated runs of one problem. Durations are in
+// nanoseconds when encoded as JSON.
+type BenchResult struct {
+	Year   int           `json:"year"`
+	Day    int           `json:"day"`
+	Part   int           `json:"part"`
+	Name   string        `json:"name"`
+	Short  bool          `json:"short"`
+	Runs   int           `json:"runs"`
+	Answer Answer        `json:"answer"`
+	Min    time.Duration `json:"min_ns"`
+	Median time.Duration `json:"median_ns"`
+	P95    time.Duration `json:"p95_ns"`
+	Max    time.Duration `json:"max_ns"`
+	// AllocsPerRun and BytesPerRun are the mean heap allocations per run.
+	AllocsPerRun uint64 `json:"allocs_per_run"`
+	BytesPerRun  uint64 `json:"bytes_per_run"`
+	// PeakHeap is the largest sampled growth in live heap over the heap at
+	// the start of a run.
+	PeakHeap uint64 `json:"peak_heap_bytes"`
+	Err      error  `json:"-"`
+	// Error holds Err's message for JSON output.
+	Error string `json:"error,omitempty"`
+}
+
+// BenchReport is a set of results together with where they were measured,
+// so that reports from different commits can be compared.
+type BenchReport struct {
+	Time     time.Time     `json:"time"`
+	Go       string        `json:"go"`
+	OS       string        `json:"os"`
+	Arch     string        `json:"arch"`
+	CPUs     int           `json:"cpus"`
+	Revision string        `json:"revision,omitempty"`
+	Results  []BenchResult `json:"results"`
+}
+
+// NewBenchReport returns a report describing the current build and machine.
+// Revision is the VCS revision stamped into the binary, when there is one.
+func NewBenchReport(results []BenchResult) BenchReport {
+	report := BenchReport{
+		Time:    time.Now().UTC(),
+		Go:      runtime.Version(),
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+		CPUs:    runtime.NumCPU(),
+		Results: results,
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				report.Revision = s.Value
+			}
+		}
+	}
+	return report
+}
+
+// Bench solves a registered problem opts.Runs times, each with a fresh
+// Problem from info.New, and summarises the wall time and memory use. It
+// stops at the first run that fails, recording the error.
+func Bench(ctx context.Context, info ProblemInfo, opts BenchOptions) BenchResult {
+	result := BenchResult{Year: info.Year, Day: info.Day, Part: info.Part, Short: opts.Short}
+	runs := max(opts.Runs, 1)
+	sampleEvery := opts.SampleEvery
+	if sampleEvery <= 0 {
+		sampleEvery = 5 * time.Millisecond
+	}
+	durations := make([]time.Duration, 0, runs)
+	var allocs, bytes uint64
+	for range runs {
+		problem := info.New()
+		result.Name = problem.GetProblemName()
+		runCtx, cancel := WithTimeout(ctx, opts.Timeout)
+		m := measure(sampleEvery, func() {
+			result.Answer, result.Err = solveContext(runCtx, problem, opts.Short)
+		})
+		cancel()
+		if result.Err != nil {
+			result.Error = result.Err.Error()
+			break
+		}
+		durations = append(durations, m.duration)
+		allocs += m.allocs
+		bytes += m.bytes
+		result.PeakHeap = max(result.PeakHeap, m.peakHeap)
+	}
+	result.Runs = len(durations)
+	if result.Runs == 0 {
+		return result
+	}
+	slices.Sort(durations)
+	result.Min = durations[0]
+	result.Median = percentile(durations, 50)
+	result.P95 = percentile(durations, 95)
+	result.Max = durations[len(durations)-1]
+	result.AllocsPerRun = allocs / uint64(result.Runs)
+	result.BytesPerRun = bytes / uint64(result.Runs)
+	return result
+}
+
+// measurement is the cost of a single call measured by measure.
+type measurement struct {
+	duration time.Duration
+	allocs   uint64
+	bytes    uint64
+	peakHeap uint64
+}
+
+// measure times f and records its allocations from runtime.MemStats, sampling
+// the live heap every sampleEvery to estimate its peak. A GC beforehand
+// keeps garbage from earlier runs out of the figures.
+func measure(sampleEvery time.Duration, f func()) measurement {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	var mu sync.Mutex
+	peak := before.HeapAlloc
+	stop := make(chan struct{})
+	sampled := make(chan struct{})
+	go func() {
+		defer close(sampled)
+		ticker := time.NewTicker(sampleEvery)
+		defer ticker.Stop()
+		var ms runtime.MemStats
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				runtime.ReadMemStats(&ms)
+				mu.Lock()
+				peak = max(peak, ms.HeapAlloc)
+				mu.Unlock()
+			}
+		}
+	}()
+
+	start := time.Now()
+	f()
+	duration := time.Since(start)
+	close(stop)
+	<-sampled
+	runtime.ReadMemStats(&after)
+
+	peak = max(peak, after.HeapAlloc)
+	return measurement{
+		duration: duration,
+		allocs:   after.Mallocs - before.Mallocs,
+		bytes:    after.TotalAlloc - before.TotalAlloc,
+		peakHeap: peak - before.HeapAlloc,
+	}
+}
+
+// percentile returns the nearest-rank p'th percentile of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// BenchmarkProblem benchmarks a problem's full answer, or its short answer
+// when short is true, failing the benchmark if it returns an error.
+func BenchmarkProblem(b *testing.B, problem Problem, short bool) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := solveContext(context.Background(), problem, short); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkRegistry runs BenchmarkProblem for every problem in r as a
+// sub-benchmark named after its dayX-Y folder, so a single Benchmark function
+// in a package that links in the solutions covers them all:
+//
+//	func BenchmarkSolutions(b *testing.B) {
+//		eulerlib.BenchmarkRegistry(b, eulerlib.DefaultRegistry(), true)
+//	}
+func BenchmarkRegistry(b *testing.B, r *Registry, short bool) {
+	for _, info := range r.All() {
+		b.Run(info.FolderName(), func(b *testing.B) {
+			BenchmarkProblem(b, info.New(), short)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package eulerlib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []TTest{
+		{Name: "min", Input: 0, Expect: time.Duration(1)},
+		{Name: "median", Input: 50, Expect: time.Duration(5)},
+		{Name: "p95", Input: 95, Expect: time.Duration(10)},
+		{Name: "max", Input: 100, Expect: time.Duration(10)},
+	}
+	for _, test := range tests {
+		CheckTest(t, "bench.percentile", test, percentile(sorted, test.Input.(int)))
+	}
+	CheckTest(t, "bench.percentile", TTest{Name: "single", Expect: time.Duration(7)}, percentile([]time.Duration{7}, 95))
+}
+
+// TAllocProblem allocates and holds about 1MiB while it solves.
+type TAllocProblem struct {
+	Problem
+}
+
+func (m *TAllocProblem) GetProblemName() string { return "Day 1, Part 1" }
+
+func (m *TAllocProblem) GetAnswer() string { return "1048576" }
+
+var benchSink []byte
+
+func (m *TAllocProblem) GenerateAnswer() string {
+	benchSink = make([]byte, 1<<20)
+	time.Sleep(10 * time.Millisecond)
+	n := len(benchSink)
+	benchSink = nil
+	return IntToStr(n)
+}
+
+func TestBench(t *testing.T) {
+	info := ProblemInfo{Year: 2025, Day: 1, Part: 1, New: func() Problem { return &TAllocProblem{} }}
+	result := Bench(context.Background(), info, BenchOptions{Runs: 3, SampleEvery: time.Millisecond})
+	if result.Err != nil {
+		t.Fatal(result.Err)
+	}
+	CheckTest(t, "bench.Bench", TTest{Name: "runs", Expect: 3}, result.Runs)
+	CheckTest(t, "bench.Bench", TTest{Name: "answer", Expect: "1048576"}, result.Answer.String())
+	if result.Min < 10*time.Millisecond || result.Min > result.Median || result.Median > result.P95 || result.P95 > result.Max {
+		t.Errorf("expected ordered durations of at least 10ms, got %v %v %v %v", result.Min, result.Median, result.P95, result.Max)
+	}
+	if result.BytesPerRun < 1<<20 || result.AllocsPerRun == 0 {
+		t.Errorf("expected at least 1MiB allocated per run, got %d bytes in %d allocations", result.BytesPerRun, result.AllocsPerRun)
+	}
+	if result.PeakHeap < 1<<20 {
+		t.Errorf("expected a peak heap of at least 1MiB, got %d", result.PeakHeap)
+	}
+
+	b, err := json.Marshal(NewBenchReport([]BenchResult{result}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"median_ns":`, `"peak_heap_bytes":`, `"go":"go`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected JSON to contain %s, got %s", want, b)
+		}
+	}
+}
+
+func TestBenchStopsOnError(t *testing.T) {
+	info := ProblemInfo{Year: 2025, Day: 1, Part: 1, New: func() Problem { return &TBlockingProblem{stopped: make(chan struct{})} }}
+	result := Bench(context.Background(), info, BenchOptions{Runs: 5, Timeout: 5 * time.Millisecond})
+	CheckTest(t, "bench.Bench", TTest{Name: "runs", Expect: 0}, result.Runs)
+	if !errors.Is(result.Err, context.DeadlineExceeded) || result.Error != "timed out after 5ms" {
+		t.Errorf("expected a timeout, got %v", result.Err)
+	}
+}
+
+func TestBenchmarkProblem(t *testing.T) {
+	r := testing.Benchmark(func(b *testing.B) {
+		BenchmarkProblem(b, &TTestProblem{}, false)
+	})
+	if r.N == 0 {
+		t.Error("expected the benchmark to run")
+	}
+}
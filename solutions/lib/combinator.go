@@ -0,0 +1,520 @@
+package eulerlib
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parser reads a T from the front of its input. Parsers are built from the
+// primitives below (Lit, Int, Ident, OneOf, ...) and combined with Seq2,
+// Choice, Many, SepBy, Between and friends, then run with Parse.
+//
+// A parser that fails after consuming input commits the parse: Choice,
+// Optional and Many do not try anything else and the failure is reported.
+// Wrap a parser in Try to let it backtrack. The exception is SepBy, which
+// gives back a separator that no item follows.
+type Parser[T any] func(st *parseState) (T, bool)
+
+// parseState is the input being parsed and the furthest failure so far,
+// which is the one reported when the parse fails.
+type parseState struct {
+	src string
+	pos int
+	// failPos is the offset of the furthest failure, or -1.
+	failPos int
+	// expected lists what the parsers failing at failPos were looking for.
+	expected []string
+	// msg and err describe a failure that is not a missing token, such as a
+	// value rejected by Validate. They take priority over expected.
+	msg string
+	err error
+}
+
+// fail records that something described by expected was not found at pos.
+func (m *parseState) fail(pos int, expected string) {
+	switch {
+	case pos > m.failPos:
+		m.failPos, m.expected, m.msg, m.err = pos, []string{expected}, "", nil
+	case pos == m.failPos && !slices.Contains(m.expected, expected):
+		m.expected = append(m.expected, expected)
+	}
+}
+
+// failMsg records a failure at pos with its own message. It replaces any
+// failure recorded so far, as it is not a lookahead that might be retried.
+func (m *parseState) failMsg(pos int, msg string, err error) {
+	m.failPos, m.expected, m.msg, m.err = pos, nil, msg, err
+}
+
+// error returns the furthest failure as a ParseError.
+func (m *parseState) error() *ParseError {
+	pos := max(m.failPos, 0)
+	lineStart := strings.LastIndexByte(m.src[:pos], '\n') + 1
+	lineEnd := strings.IndexByte(m.src[pos:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(m.src)
+	} else {
+		lineEnd += pos
+	}
+	perr := &ParseError{
+		Line: strings.Count(m.src[:pos], "\n") + 1,
+		Col:  utf8.RuneCountInString(m.src[lineStart:pos]) + 1,
+		Text: strings.TrimSuffix(m.src[lineStart:lineEnd], "\r"),
+		Msg:  m.msg,
+		Err:  m.err,
+	}
+	if perr.Msg == "" {
+		perr.Msg = fmt.Sprintf("expected %s, got %s", joinAlternatives(m.expected), describeNext(m.src[pos:]))
+	}
+	return perr
+}
+
+// joinAlternatives joins descriptions as "a", "a or b" or "a, b or c".
+func joinAlternatives(items []string) string {
+	if len(items) == 0 {
+		return "something else"
+	}
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
+
+// describeNext describes the input a parser failed at.
+func describeNext(rest string) string {
+	if rest == "" {
+		return "end of input"
+	}
+	if rest[0] == '\n' || strings.HasPrefix(rest, "\r\n") {
+		return "end of line"
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return strconv.Quote(string(r))
+}
+
+// Parse runs p over the whole of s. Anything but trailing white space left
+// over after p is an error. Errors are *ParseError values giving the line
+// and column of the furthest point the parse reached.
+func Parse[T any](p Parser[T], s string) (T, error) {
+	st := &parseState{src: s, failPos: -1}
+	v, ok := p(st)
+	if ok {
+		rest := strings.TrimRight(s[st.pos:], " \t\r\n")
+		if rest == "" {
+			return v, nil
+		}
+		st.fail(st.pos, "end of input")
+	}
+	var zero T
+	return zero, st.error()
+}
+
+// ParseInput runs p over lines joined by newlines, as loaded by LoadInput.
+func ParseInput[T any](p Parser[T], lines []string) (T, error) {
+	return Parse(p, strings.Join(lines, "\n"))
+}
+
+// MustParse is like Parse but panics if s cannot be parsed.
+func MustParse[T any](p Parser[T], s string) T {
+	v, err := Parse(p, s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Lit matches the literal text s.
+func Lit(s string) Parser[string] {
+	expected := strconv.Quote(s)
+	return func(st *parseState) (string, bool) {
+		if !strings.HasPrefix(st.src[st.pos:], s) {
+			st.fail(st.pos, expected)
+			return "", false
+		}
+		st.pos += len(s)
+		return s, true
+	}
+}
+
+// OneOf matches any single character in chars.
+func OneOf(chars string) Parser[rune] {
+	expected := "one of " + strconv.Quote(chars)
+	return func(st *parseState) (rune, bool) {
+		r, size := utf8.DecodeRuneInString(st.src[st.pos:])
+		if size == 0 || !strings.ContainsRune(chars, r) {
+			st.fail(st.pos, expected)
+			return 0, false
+		}
+		st.pos += size
+		return r, true
+	}
+}
+
+// Regexp matches the regular expression expr at the current position,
+// returning the text it matched. It panics if expr does not compile.
+func Regexp(expr string) Parser[string] {
+	re := regexp.MustCompile(`\A(?:` + expr + `)`)
+	expected := "text matching " + strconv.Quote(expr)
+	return func(st *parseState) (string, bool) {
+		loc := re.FindStringIndex(st.src[st.pos:])
+		if loc == nil {
+			st.fail(st.pos, expected)
+			return "", false
+		}
+		s := st.src[st.pos : st.pos+loc[1]]
+		st.pos += loc[1]
+		return s, true
+	}
+}
+
+// Int matches a decimal integer with an optional sign. An integer that does
+// not fit in an int is an error rather than a mismatch.
+func Int() Parser[int] {
+	return func(st *parseState) (int, bool) {
+		start, end := st.pos, st.pos
+		if end < len(st.src) && (st.src[end] == '-' || st.src[end] == '+') {
+			end++
+		}
+		digits := end
+		for end < len(st.src) && st.src[end] >= '0' && st.src[end] <= '9' {
+			end++
+		}
+		if end == digits {
+			st.fail(start, "an integer")
+			return 0, false
+		}
+		st.pos = end
+		v, err := strconv.Atoi(st.src[start:end])
+		if err != nil {
+			st.failMsg(start, intError(st.src[start:end], err).Error(), errors.Unwrap(err))
+			return 0, false
+		}
+		return v, true
+	}
+}
+
+// Ident matches an identifier: a letter or underscore followed by letters,
+// digits and underscores.
+func Ident() Parser[string] {
+	return func(st *parseState) (string, bool) {
+		end := st.pos
+		for i, r := range st.src[st.pos:] {
+			if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+				break
+			}
+			end = st.pos + i + utf8.RuneLen(r)
+		}
+		if end == st.pos {
+			st.fail(st.pos, "an identifier")
+			return "", false
+		}
+		s := st.src[st.pos:end]
+		st.pos = end
+		return s, true
+	}
+}
+
+// Spaces matches one or more spaces or tabs.
+func Spaces() Parser[string] {
+	return Label(Regexp(`[ \t]+`), "a space")
+}
+
+// Newline matches the end of a line, "\n" or "\r\n".
+func Newline() Parser[string] {
+	return Label(Regexp(`\r?\n`), "end of line")
+}
+
+// BlankLines matches the end of a line followed by one or more blank lines,
+// the separator between the blocks of an input.
+func BlankLines() Parser[string] {
+	return Label(Regexp(`\r?\n(?:[ \t]*\r?\n)+`), "a blank line")
+}
+
+// EOF matches the end of the input.
+func EOF() Parser[struct{}] {
+	return func(st *parseState) (struct{}, bool) {
+		if st.pos < len(st.src) {
+			st.fail(st.pos, "end of input")
+			return struct{}{}, false
+		}
+		return struct{}{}, true
+	}
+}
+
+// Map converts the value of p with f.
+func Map[T, U any](p Parser[T], f func(T) U) Parser[U] {
+	return func(st *parseState) (U, bool) {
+		v, ok := p(st)
+		if !ok {
+			var zero U
+			return zero, false
+		}
+		return f(v), true
+	}
+}
+
+// Validate converts the value of p with f, failing with f's error, reported
+// at the start of the value, when the value is not acceptable.
+func Validate[T, U any](p Parser[T], f func(T) (U, error)) Parser[U] {
+	return func(st *parseState) (U, bool) {
+		start := st.pos
+		v, ok := p(st)
+		if !ok {
+			var zero U
+			return zero, false
+		}
+		u, err := f(v)
+		if err != nil {
+			st.failMsg(start, err.Error(), err)
+			return u, false
+		}
+		return u, true
+	}
+}
+
+// Label names what p matches, so that a failure of p before it consumes any
+// input is reported as "expected <name>" rather than in terms of p's parts.
+func Label[T any](p Parser[T], name string) Parser[T] {
+	return func(st *parseState) (T, bool) {
+		start := st.pos
+		failPos, expected, msg, err := st.failPos, st.expected, st.msg, st.err
+		v, ok := p(st)
+		if !ok && st.pos == start && st.failPos <= start && st.msg == "" {
+			st.failPos, st.expected, st.msg, st.err = failPos, expected, msg, err
+			st.fail(start, name)
+		}
+		return v, ok
+	}
+}
+
+// Try runs p, rewinding to where it started if it fails, so that a choice
+// can try another alternative even though p consumed input.
+func Try[T any](p Parser[T]) Parser[T] {
+	return func(st *parseState) (T, bool) {
+		start := st.pos
+		v, ok := p(st)
+		if !ok {
+			st.pos = start
+		}
+		return v, ok
+	}
+}
+
+// Lazy defers building a parser until it is first run, for recursive
+// grammars.
+func Lazy[T any](build func() Parser[T]) Parser[T] {
+	p := sync.OnceValue(build)
+	return func(st *parseState) (T, bool) {
+		return p()(st)
+	}
+}
+
+// Choice returns the value of the first of ps to succeed. An alternative
+// that fails after consuming input ends the choice unless it is wrapped in
+// Try.
+func Choice[T any](ps ...Parser[T]) Parser[T] {
+	return func(st *parseState) (T, bool) {
+		start := st.pos
+		for _, p := range ps {
+			v, ok := p(st)
+			if ok || st.pos != start {
+				return v, ok
+			}
+		}
+		var zero T
+		return zero, false
+	}
+}
+
+// Optional returns the value of p, or def if p fails without consuming
+// input.
+func Optional[T any](p Parser[T], def T) Parser[T] {
+	return func(st *parseState) (T, bool) {
+		start := st.pos
+		v, ok := p(st)
+		if !ok && st.pos == start {
+			return def, true
+		}
+		return v, ok
+	}
+}
+
+// Many matches p zero or more times.
+func Many[T any](p Parser[T]) Parser[[]T] {
+	return func(st *parseState) ([]T, bool) {
+		values := []T{}
+		for {
+			start := st.pos
+			v, ok := p(st)
+			if !ok {
+				return values, st.pos == start
+			}
+			values = append(values, v)
+			if st.pos == start {
+				//p matched nothing, so it would match forever
+				return values, true
+			}
+		}
+	}
+}
+
+// Many1 matches p one or more times.
+func Many1[T any](p Parser[T]) Parser[[]T] {
+	return Seq2(p, Many(p), func(first T, rest []T) []T {
+		return append([]T{first}, rest...)
+	})
+}
+
+// SepBy matches zero or more p separated by sep. A separator that no item
+// follows is left unconsumed, so a list can be followed by more of the
+// separator, such as SepBy(item, Spaces()) before a space and a trailer.
+func SepBy[T, S any](p Parser[T], sep Parser[S]) Parser[[]T] {
+	return func(st *parseState) ([]T, bool) {
+		start := st.pos
+		first, ok := p(st)
+		if !ok {
+			return []T{}, st.pos == start
+		}
+		return sepByRest(st, first, p, sep)
+	}
+}
+
+// SepBy1 matches one or more p separated by sep.
+func SepBy1[T, S any](p Parser[T], sep Parser[S]) Parser[[]T] {
+	return func(st *parseState) ([]T, bool) {
+		first, ok := p(st)
+		if !ok {
+			return nil, false
+		}
+		return sepByRest(st, first, p, sep)
+	}
+}
+
+// sepByRest matches the separated items that follow first.
+func sepByRest[T, S any](st *parseState, first T, p Parser[T], sep Parser[S]) ([]T, bool) {
+	values := []T{first}
+	for {
+		beforeSep := st.pos
+		if _, ok := sep(st); !ok {
+			return values, st.pos == beforeSep
+		}
+		afterSep := st.pos
+		v, ok := p(st)
+		if !ok {
+			if st.pos == afterSep {
+				st.pos = beforeSep
+				return values, true
+			}
+			return values, false
+		}
+		values = append(values, v)
+	}
+}
+
+// Between matches open, p and close, returning the value of p.
+func Between[O, T, C any](open Parser[O], p Parser[T], close Parser[C]) Parser[T] {
+	return Seq3(open, p, close, func(_ O, v T, _ C) T { return v })
+}
+
+// Preceded matches prefix then p, returning the value of p.
+func Preceded[P, T any](prefix Parser[P], p Parser[T]) Parser[T] {
+	return Seq2(prefix, p, func(_ P, v T) T { return v })
+}
+
+// Terminated matches p then suffix, returning the value of p.
+func Terminated[T, S any](p Parser[T], suffix Parser[S]) Parser[T] {
+	return Seq2(p, suffix, func(v T, _ S) T { return v })
+}
+
+// Seq matches each of ps in turn, returning their values.
+func Seq[T any](ps ...Parser[T]) Parser[[]T] {
+	return func(st *parseState) ([]T, bool) {
+		values := make([]T, 0, len(ps))
+		for _, p := range ps {
+			v, ok := p(st)
+			if !ok {
+				return values, false
+			}
+			values = append(values, v)
+		}
+		return values, true
+	}
+}
+
+// Seq2 matches a then b, combining their values with f.
+func Seq2[A, B, R any](a Parser[A], b Parser[B], f func(A, B) R) Parser[R] {
+	return func(st *parseState) (R, bool) {
+		var zero R
+		va, ok := a(st)
+		if !ok {
+			return zero, false
+		}
+		vb, ok := b(st)
+		if !ok {
+			return zero, false
+		}
+		return f(va, vb), true
+	}
+}
+
+// Seq3 matches a, b and c in turn, combining their values with f.
+func Seq3[A, B, C, R any](a Parser[A], b Parser[B], c Parser[C], f func(A, B, C) R) Parser[R] {
+	return func(st *parseState) (R, bool) {
+		var zero R
+		va, ok := a(st)
+		if !ok {
+			return zero, false
+		}
+		vb, ok := b(st)
+		if !ok {
+			return zero, false
+		}
+		vc, ok := c(st)
+		if !ok {
+			return zero, false
+		}
+		return f(va, vb, vc), true
+	}
+}
+
+// Seq4 matches a, b, c and d in turn, combining their values with f.
+func Seq4[A, B, C, D, R any](a Parser[A], b Parser[B], c Parser[C], d Parser[D], f func(A, B, C, D) R) Parser[R] {
+	return func(st *parseState) (R, bool) {
+		var zero R
+		va, ok := a(st)
+		if !ok {
+			return zero, false
+		}
+		vb, ok := b(st)
+		if !ok {
+			return zero, false
+		}
+		vc, ok := c(st)
+		if !ok {
+			return zero, false
+		}
+		vd, ok := d(st)
+		if !ok {
+			return zero, false
+		}
+		return f(va, vb, vc, vd), true
+	}
+}
+
+// Lines matches one or more p, one per line.
+func Lines[T any](p Parser[T]) Parser[[]T] {
+	return SepBy1(p, Newline())
+}
+
+// Blocks matches one or more p separated by blank lines, for inputs made of
+// paragraphs.
+func Blocks[T any](p Parser[T]) Parser[[]T] {
+	return SepBy1(p, BlankLines())
+}
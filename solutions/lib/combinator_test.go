@@ -0,0 +1,177 @@
+package eulerlib
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// testMachine is the shape of a day 10 machine line.
+type testMachine struct {
+	lights  string
+	buttons [][]int
+	joltage []int
+}
+
+func testMachineParser() Parser[testMachine] {
+	ints := func(open, close string) Parser[[]int] {
+		return Between(Lit(open), SepBy(Int(), Lit(",")), Lit(close))
+	}
+	return Seq3(
+		Between(Lit("["), Regexp(`[.#]*`), Lit("]")),
+		Preceded(Spaces(), SepBy(ints("(", ")"), Spaces())),
+		Preceded(Spaces(), ints("{", "}")),
+		func(lights string, buttons [][]int, joltage []int) testMachine {
+			return testMachine{lights, buttons, joltage}
+		},
+	)
+}
+
+func TestParseMachine(t *testing.T) {
+	p := Lines(testMachineParser())
+	machines, err := Parse(p, "[.##.] (3) (1,3) {3,5,4,7}\n[#] (0) {1}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "combinator.Parse", TTest{Name: "machines", Expect: []testMachine{
+		{".##.", [][]int{{3}, {1, 3}}, []int{3, 5, 4, 7}},
+		{"#", [][]int{{0}}, []int{1}},
+	}}, machines)
+
+	tests := []struct {
+		name, input, expect string
+	}{
+		{"bad light", "[.x]", `line 1, col 3: expected "]", got "x" in "[.x]"`},
+		{"bad button", "[.] (1,x) {1}", `line 1, col 8: expected an integer, got "x" in "[.] (1,x) {1}"`},
+		{"missing joltage", "[.] (1)", `line 1, col 8: expected a space, got end of input in "[.] (1)"`},
+		{"stray text", "[.] (1) x {1}", `line 1, col 9: expected "(" or "{", got "x" in "[.] (1) x {1}"`},
+		{"second line", "[.] (1) {1}\n[.] (1) {1", `line 2, col 11: expected "," or "}", got end of input in "[.] (1) {1"`},
+		{"trailing", "[.] (1) {1} !", `line 1, col 12: expected end of line or end of input, got " " in "[.] (1) {1} !"`},
+	}
+	for _, test := range tests {
+		_, err := Parse(p, test.input)
+		got := "<nil>"
+		if err != nil {
+			got = err.Error()
+		}
+		CheckTest(t, "combinator.Parse", TTest{Name: test.name, Expect: test.expect}, got)
+	}
+}
+
+// testRegion is the shape of a day 12 region line.
+type testRegion struct {
+	width, height int
+	counts        []int
+}
+
+func TestParseBlocks(t *testing.T) {
+	piece := Preceded(
+		Try(Terminated(Int(), Seq(Lit(":"), Newline()))),
+		Lines(Label(Regexp(`[#.]+`), "a row of # and .")),
+	)
+	region := Seq3(
+		Terminated(Int(), Lit("x")),
+		Terminated(Int(), Lit(": ")),
+		SepBy(Int(), Spaces()),
+		func(w, h int, counts []int) testRegion { return testRegion{w, h, counts} },
+	)
+	type input struct {
+		pieces  [][]string
+		regions []testRegion
+	}
+	p := Seq2(Terminated(Blocks(piece), BlankLines()), Lines(region), func(pieces [][]string, regions []testRegion) input {
+		return input{pieces, regions}
+	})
+	lines := []string{"0:", "###", "#..", "", "1:", ".#.", "", "", "4x4: 0 2", "12x5: 1 0"}
+	got, err := ParseInput(p, lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "combinator.Blocks", TTest{Name: "input", Expect: input{
+		pieces:  [][]string{{"###", "#.."}, {".#."}},
+		regions: []testRegion{{4, 4, []int{0, 2}}, {12, 5, []int{1, 0}}},
+	}}, got)
+
+	_, err = ParseInput(p, []string{"0:", "#x#", "", "4x4: 1"})
+	CheckTest(t, "combinator.Blocks", TTest{Name: "bad row", Expect: `line 2, col 2: expected end of line or a blank line, got "x" in "#x#"`}, err.Error())
+	_, err = ParseInput(p, []string{"0:", "", "4x4: 1"})
+	CheckTest(t, "combinator.Blocks", TTest{Name: "empty piece", Expect: `line 2, col 1: expected a row of # and ., got end of line in ""`}, err.Error())
+}
+
+func TestParseBacktracking(t *testing.T) {
+	keyword := Choice(Lit("let"), Lit("loop"))
+	CheckTest(t, "combinator.Choice", TTest{Name: "second", Expect: "loop"}, MustParse(keyword, "loop"))
+
+	//"le" is consumed before "let" fails, so the choice is committed
+	committed := Choice(Seq(Lit("le"), Lit("t")), Seq(Lit("le"), Lit("ft")))
+	_, err := Parse(committed, "left")
+	CheckTest(t, "combinator.Choice", TTest{Name: "committed", Expect: `line 1, col 3: expected "t", got "f" in "left"`}, err.Error())
+	backtracking := Choice(Try(Seq(Lit("le"), Lit("t"))), Seq(Lit("le"), Lit("ft")))
+	CheckTest(t, "combinator.Try", TTest{Name: "backtracked", Expect: []string{"le", "ft"}}, MustParse(backtracking, "left"))
+
+	signed := Optional(OneOf("+-"), '+')
+	CheckTest(t, "combinator.Optional", TTest{Name: "default", Expect: '+'}, MustParse(signed, ""))
+	CheckTest(t, "combinator.Optional", TTest{Name: "present", Expect: '-'}, MustParse(signed, "-"))
+	_, err = Parse(Optional(Seq(Lit("a"), Lit("b")), nil), "ac")
+	CheckTest(t, "combinator.Optional", TTest{Name: "committed", Expect: `line 1, col 2: expected "b", got "c" in "ac"`}, err.Error())
+
+	//Many stops rather than looping on a parser that matches nothing
+	CheckTest(t, "combinator.Many", TTest{Name: "empty match", Expect: []string{""}}, MustParse(Many(Regexp(`x*`)), ""))
+}
+
+func TestParseValues(t *testing.T) {
+	CheckTest(t, "combinator.Ident", TTest{Name: "ident", Expect: []string{"a_1", "_b"}}, MustParse(SepBy1(Ident(), Lit(" ")), "a_1 _b"))
+	_, err := Parse(Ident(), "1a")
+	CheckTest(t, "combinator.Ident", TTest{Name: "digit first", Expect: `line 1, col 1: expected an identifier, got "1" in "1a"`}, err.Error())
+
+	CheckTest(t, "combinator.Int", TTest{Name: "signed", Expect: []int{-3, 4, 5}}, MustParse(SepBy(Int(), Lit(",")), "-3,+4,5"))
+	_, err = Parse(Int(), "99999999999999999999")
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("expected a range error, got %v", err)
+	}
+
+	even := Validate(Int(), func(n int) (int, error) {
+		if n%2 != 0 {
+			return 0, fmt.Errorf("%d is odd", n)
+		}
+		return n, nil
+	})
+	_, err = Parse(SepBy(even, Lit(",")), "2,13")
+	CheckTest(t, "combinator.Validate", TTest{Name: "odd", Expect: `line 1, col 3: 13 is odd in "2,13"`}, err.Error())
+
+	double := Map(Int(), func(n int) int { return n * 2 })
+	CheckTest(t, "combinator.Map", TTest{Name: "double", Expect: 14}, MustParse(double, "7"))
+	CheckTest(t, "combinator.Many1", TTest{Name: "chars", Expect: []rune("#.#")}, MustParse(Many1(OneOf("#.")), "#.#"))
+	CheckTest(t, "combinator.EOF", TTest{Name: "end", Expect: "x"}, MustParse(Terminated(Lit("x"), EOF()), "x"))
+}
+
+// nested is a recursive list such as [1,[2,3]].
+type nested struct {
+	value int
+	items []nested
+}
+
+func TestParseRecursive(t *testing.T) {
+	var value Parser[nested]
+	value = Choice(
+		Map(Int(), func(n int) nested { return nested{value: n} }),
+		Map(Between(Lit("["), SepBy(Lazy(func() Parser[nested] { return value }), Lit(",")), Lit("]")), func(items []nested) nested {
+			return nested{items: items}
+		}),
+	)
+	got := MustParse(value, "[1,[2,[]]]")
+	CheckTest(t, "combinator.Lazy", TTest{Name: "nested", Expect: nested{items: []nested{
+		{value: 1},
+		{items: []nested{{value: 2}, {items: []nested{}}}},
+	}}}, got)
+	_, err := Parse(value, "[1,[2,]]")
+	CheckTest(t, "combinator.Lazy", TTest{Name: "error", Expect: `line 1, col 7: expected an integer or "[", got "]" in "[1,[2,]]"`}, err.Error())
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected MustParse to panic")
+		}
+	}()
+	MustParse(value, "[")
+}
@@ -0,0 +1,139 @@
+package eulerlib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the per-problem deadline applied by TestProblem and the
+// aoc runner. It is comfortably below go test's own ten minute timeout so a
+// stuck solution is reported rather than aborting the whole test binary.
+const DefaultTimeout = 5 * time.Minute
+
+// TimeoutEnv names the environment variable that overrides the timeout used
+// by TestProblem. It takes a Go duration such as "30s"; "0" disables it.
+const TimeoutEnv = "AOC_TIMEOUT"
+
+// ContextProblem is implemented by solutions that can stop early when their
+// context is cancelled. TestProblem and the runner call SolveContext in place
+// of the Generate methods, with short selecting the sample input.
+type ContextProblem interface {
+	Problem
+	SolveContext(ctx context.Context, short bool) (Answer, error)
+}
+
+// TimeoutError reports a problem that did not finish within its deadline.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+// Error returns "timed out after" the configured timeout.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s", e.Timeout)
+}
+
+// Is lets errors.Is(err, context.DeadlineExceeded) recognise a timeout.
+func (e *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// WithTimeout returns a copy of ctx that is cancelled after d with a
+// TimeoutError as its cause. A d of zero or less applies no deadline.
+func WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, d, &TimeoutError{Timeout: d})
+}
+
+// TestTimeout returns the timeout TestProblem applies: AOC_TIMEOUT when it is
+// set to a valid duration and DefaultTimeout otherwise.
+func TestTimeout() time.Duration {
+	if s := os.Getenv(TimeoutEnv); s != "" {
+		if d, err := time.ParseDuration(s); err == nil {
+			return d
+		}
+	}
+	return DefaultTimeout
+}
+
+// solveContext generates the full or short answer for problem, passing ctx
+// to ContextProblems, and its Debugger, Task and strict mode to
+// LoggerProblems, ProgressProblems and StrictProblems.
+func solveContext(ctx context.Context, problem Problem, short bool) (Answer, error) {
+	scopeProblem(ctx, problem)
+	return generateContext(ctx, func() (Answer, error) {
+		if cp, ok := problem.(ContextProblem); ok {
+			return cp.SolveContext(ctx, short)
+		}
+		ap := AsAnswerProblem(problem)
+		if short {
+			return ap.ShortAnswer()
+		}
+		return ap.Answer()
+	})
+}
+
+// generateContext calls generate, returning as soon as ctx is done. Solutions
+// that watch ctx are expected to stop when it is cancelled; others cannot be
+// interrupted and are left to finish in the background once their result has
+// been abandoned. A cancelled ctx is reported by its cause, such as a
+// TimeoutError, and generate is not called at all if ctx is already done.
+func generateContext(ctx context.Context, generate func() (Answer, error)) (Answer, error) {
+	if ctx.Err() != nil {
+		return Answer{}, context.Cause(ctx)
+	}
+	type outcome struct {
+		answer Answer
+		err    error
+	}
+	done := make(chan outcome, 1)
+	solves, _ := ctx.Value(solvesKey{}).(*sync.WaitGroup)
+	if solves != nil {
+		solves.Add(1)
+	}
+	go func() {
+		if solves != nil {
+			defer solves.Done()
+		}
+		var o outcome
+		o.answer, o.err = generateAnswer(generate)
+		done <- o
+	}()
+	select {
+	case o := <-done:
+		if o.err != nil && ctx.Err() != nil {
+			return o.answer, context.Cause(ctx)
+		}
+		return o.answer, o.err
+	case <-ctx.Done():
+		return Answer{}, context.Cause(ctx)
+	}
+}
+
+// solvesKey is the context key for the WaitGroup set by withSolves.
+type solvesKey struct{}
+
+// withSolves returns a copy of ctx under which generateContext counts the
+// solves it starts in solves until they return, including those it stopped
+// waiting for.
+func withSolves(ctx context.Context, solves *sync.WaitGroup) context.Context {
+	return context.WithValue(ctx, solvesKey{}, solves)
+}
+
+// scopeProblem gives a LoggerProblem the Debugger from ctx, a ProgressProblem
+// its Task and a StrictProblem its strict mode.
+func scopeProblem(ctx context.Context, problem Problem) {
+	if lp, ok := problem.(LoggerProblem); ok {
+		lp.SetLogger(DebuggerFrom(ctx))
+	}
+	if pp, ok := problem.(ProgressProblem); ok {
+		pp.SetProgress(ProgressFrom(ctx))
+	}
+	if sp, ok := problem.(StrictProblem); ok {
+		sp.SetStrict(StrictFrom(ctx))
+	}
+}
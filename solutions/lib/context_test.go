@@ -0,0 +1,149 @@
+package eulerlib
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TBlockingProblem never produces an answer on its own; it waits for its
+// context to be cancelled and records that it noticed.
+type TBlockingProblem struct {
+	Problem
+	stopped chan struct{}
+}
+
+func (m *TBlockingProblem) GetProblemName() string { return "Blocking Problem" }
+
+func (m *TBlockingProblem) GetAnswer() string { return "1" }
+
+func (m *TBlockingProblem) SolveContext(ctx context.Context, short bool) (Answer, error) {
+	<-ctx.Done()
+	close(m.stopped)
+	return Answer{}, ctx.Err()
+}
+
+// TContextProblem answers through SolveContext, using short to pick a value.
+type TContextProblem struct {
+	Problem
+}
+
+func (m *TContextProblem) GetProblemName() string { return "Context Problem" }
+
+func (m *TContextProblem) GetAnswer() string { return "100" }
+
+func (m *TContextProblem) GetShortAnswer() string { return "10" }
+
+func (m *TContextProblem) SolveContext(ctx context.Context, short bool) (Answer, error) {
+	if short {
+		return IntAnswer(10), nil
+	}
+	return IntAnswer(100), nil
+}
+
+// TSlowProblem ignores its context and takes longer than the test timeouts.
+type TSlowProblem struct {
+	Problem
+}
+
+func (m *TSlowProblem) GetProblemName() string { return "Slow Problem" }
+
+func (m *TSlowProblem) GetAnswer() string { return "1" }
+
+func (m *TSlowProblem) GenerateAnswer() string {
+	time.Sleep(time.Second)
+	return "1"
+}
+
+func TestTimeoutError(t *testing.T) {
+	err := error(&TimeoutError{Timeout: 3 * time.Second})
+	CheckTest(t, "context.TimeoutError", TTest{Name: "message", Expect: "timed out after 3s"}, err.Error())
+	CheckTest(t, "context.TimeoutError", TTest{Name: "is deadline", Expect: true}, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestWithTimeout(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	var timeout *TimeoutError
+	if !errors.As(context.Cause(ctx), &timeout) {
+		t.Errorf("expected a TimeoutError cause, got %v", context.Cause(ctx))
+	}
+
+	ctx, cancel = WithTimeout(context.Background(), 0)
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline for a zero timeout")
+	}
+	cancel()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected cancel to cancel the context, got %v", ctx.Err())
+	}
+}
+
+func TestTestTimeout(t *testing.T) {
+	tests := []TTest{
+		{Name: "unset", Input: "", Expect: DefaultTimeout},
+		{Name: "set", Input: "30s", Expect: 30 * time.Second},
+		{Name: "disabled", Input: "0", Expect: time.Duration(0)},
+		{Name: "invalid", Input: "soon", Expect: DefaultTimeout},
+	}
+	for _, test := range tests {
+		t.Setenv(TimeoutEnv, test.Input.(string))
+		CheckTest(t, "context.TestTimeout", test, TestTimeout())
+	}
+}
+
+func TestRunProblemContextUsesSolveContext(t *testing.T) {
+	result := RunProblemContext(context.Background(), &TContextProblem{}, true)
+	CheckTest(t, "runner.RunProblemContext", TTest{Name: "short", Expect: "pass"}, result.Status())
+	result = RunProblemContext(context.Background(), &TContextProblem{}, false)
+	CheckTest(t, "runner.RunProblemContext", TTest{Name: "long", Expect: "100"}, result.Answer.String())
+}
+
+func TestRunProblemContextCancelsContextProblem(t *testing.T) {
+	problem := &TBlockingProblem{stopped: make(chan struct{})}
+	ctx, cancel := WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	result := RunProblemContext(ctx, problem, false)
+	CheckTest(t, "runner.RunProblemContext", TTest{Name: "status", Expect: "TIMEOUT"}, result.Status())
+	if result.Err == nil || result.Err.Error() != "timed out after 10ms" {
+		t.Errorf("expected a timed out error, got %v", result.Err)
+	}
+	select {
+	case <-problem.stopped:
+	case <-time.After(time.Second):
+		t.Error("expected the problem to observe the cancelled context")
+	}
+}
+
+func TestRunProblemContextAbandonsSlowProblem(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result := RunProblemContext(ctx, &TSlowProblem{}, false)
+	if !result.TimedOut() {
+		t.Errorf("expected the slow problem to time out, got %v", result.Err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected RunProblemContext to return at the deadline, took %v", elapsed)
+	}
+}
+
+func TestTestProblemWithTimeout(t *testing.T) {
+	TestProblemWithTimeout(&TContextProblem{}, t, time.Second)
+
+	var fake testing.T
+	TestProblemWithTimeout(&TBlockingProblem{stopped: make(chan struct{})}, &fake, 10*time.Millisecond)
+	if !fake.Failed() {
+		t.Error("expected TestProblemWithTimeout to fail a problem that times out")
+	}
+}
+
+func TestSolveContextRecoversPanic(t *testing.T) {
+	_, err := solveContext(context.Background(), &TPanickingShortProblem{}, true)
+	if err == nil || !strings.Contains(err.Error(), "panic:") {
+		t.Errorf("expected a recovered panic, got %v", err)
+	}
+}
@@ -0,0 +1,256 @@
+package eulerlib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// StrictEnv names the environment variable that turns off the strict mode
+// TestProblem runs solutions in, when set to a false value such as "0".
+const StrictEnv = "AOC_STRICT"
+
+// strictKey is the context key for the strict mode set by WithStrict.
+type strictKey struct{}
+
+// WithStrict returns a copy of ctx that runs the legacy conversion helpers of
+// a solve's Conv in strict mode, or not, as on says. TestProblem uses it to
+// make each solution it runs strict without affecting others running at the
+// same time.
+func WithStrict(ctx context.Context, on bool) context.Context {
+	return context.WithValue(ctx, strictKey{}, on)
+}
+
+// StrictFrom reports whether ctx was given strict mode by WithStrict.
+func StrictFrom(ctx context.Context) bool {
+	on, _ := ctx.Value(strictKey{}).(bool)
+	return on
+}
+
+// TestStrict reports whether TestProblem runs solutions in strict mode: true
+// unless AOC_STRICT is set to a false value.
+func TestStrict() bool {
+	if s := os.Getenv(StrictEnv); s != "" {
+		if on, err := strconv.ParseBool(s); err == nil {
+			return on
+		}
+	}
+	return true
+}
+
+// StrictProblem is implemented by solutions that convert their input with a
+// Conv of their own. TestProblem and the runner call SetStrict with the
+// strict mode of the run's context before solving. Embedding Logging
+// implements it.
+type StrictProblem interface {
+	SetStrict(on bool)
+}
+
+// Conv runs the legacy conversion helpers, such as StrToInt, for one solve.
+// Text they cannot convert is read as 0, or in strict mode panics with a
+// ParseError showing the offending text. The helpers that parse lists also
+// log it to Log. The zero Conv logs to
+// the global debugger and is not strict, as the package-level helpers are.
+type Conv struct {
+	Log    *Debugger
+	Strict bool
+}
+
+// ConvFrom returns the Conv for a solve run under ctx, logging to its
+// Debugger in its strict mode.
+func ConvFrom(ctx context.Context) Conv {
+	return Conv{Log: DebuggerFrom(ctx), Strict: StrictFrom(ctx)}
+}
+
+// check panics with err, if it is not nil, in strict mode.
+func (c Conv) check(err error) {
+	if err != nil && c.Strict {
+		panic(err)
+	}
+}
+
+// fail is check that also logs err outside strict mode.
+func (c Conv) fail(err error) {
+	c.check(err)
+	if err == nil {
+		return
+	}
+	log := c.Log
+	if log == nil {
+		log = GetDebugger()
+	}
+	log.Slog().Warn("conversion failed, using 0", "err", err)
+}
+
+// conversionError describes s, which could not be read as what, as a
+// ParseError keeping strconv's ErrSyntax or ErrRange as its cause.
+func conversionError(s, what string, err error) error {
+	msg := fmt.Sprintf("expected %s, got %q", what, s)
+	if errors.Is(err, strconv.ErrRange) {
+		msg = fmt.Sprintf("%s out of range", what)
+	}
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		err = numErr.Err
+	}
+	return &ParseError{Text: s, Msg: msg, Err: err}
+}
+
+// ParseInt converts a decimal string with an optional sign to an int. Unlike
+// StrToInt it returns an error, a *ParseError wrapping strconv.ErrSyntax or
+// strconv.ErrRange, for anything else, including surrounding spaces.
+func ParseInt(s string) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, conversionError(s, "an integer", err)
+	}
+	return i, nil
+}
+
+// ParseInt64 is ParseInt for an int64.
+func ParseInt64(s string) (int64, error) {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, conversionError(s, "a 64-bit integer", err)
+	}
+	return i, nil
+}
+
+// ParseUint64 converts an unsigned decimal string to a uint64. A sign is an
+// error.
+func ParseUint64(s string) (uint64, error) {
+	u, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, conversionError(s, "an unsigned integer", err)
+	}
+	return u, nil
+}
+
+// ParseBig converts a decimal string of any length to a big.Int.
+func ParseBig(s string) (*big.Int, error) {
+	b, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, conversionError(s, "an integer", strconv.ErrSyntax)
+	}
+	return b, nil
+}
+
+// ParseHex converts a hexadecimal string, with an optional sign and "0x"
+// prefix, to an int.
+func ParseHex(s string) (int, error) {
+	return parseBase(s, 16, "0x", "a hexadecimal integer")
+}
+
+// ParseBinary converts a binary string, with an optional sign and "0b"
+// prefix, to an int.
+func ParseBinary(s string) (int, error) {
+	return parseBase(s, 2, "0b", "a binary integer")
+}
+
+// parseBase implements ParseHex and ParseBinary, stripping the sign and
+// prefix so that strconv accepts digits in base alone.
+func parseBase(s string, base int, prefix, what string) (int, error) {
+	digits, neg := s, false
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		digits, neg = digits[1:], digits[0] == '-'
+	}
+	if len(digits) > len(prefix) && strings.EqualFold(digits[:len(prefix)], prefix) {
+		digits = digits[len(prefix):]
+	}
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		return 0, conversionError(s, what, strconv.ErrSyntax)
+	}
+	if neg {
+		digits = "-" + digits
+	}
+	i, err := strconv.ParseInt(digits, base, strconv.IntSize)
+	if err != nil {
+		return 0, conversionError(s, what, err)
+	}
+	return int(i), nil
+}
+
+// ToInt converts a value of any integer type, or a decimal string, to an int,
+// returning an error for other types and for values that do not fit.
+func ToInt(a any) (int, error) {
+	switch v := a.(type) {
+	case int:
+		return v, nil
+	case int8:
+		return int(v), nil
+	case int16:
+		return int(v), nil
+	case int32:
+		return int(v), nil
+	case int64:
+		if strconv.IntSize == 32 && (v < math.MinInt32 || v > math.MaxInt32) {
+			return 0, fmt.Errorf("%d does not fit in an int: %w", v, strconv.ErrRange)
+		}
+		return int(v), nil
+	case uint8:
+		return int(v), nil
+	case uint16:
+		return int(v), nil
+	case uint32:
+		if uint64(v) > math.MaxInt {
+			return 0, fmt.Errorf("%d does not fit in an int: %w", v, strconv.ErrRange)
+		}
+		return int(v), nil
+	case uint:
+		if uint64(v) > math.MaxInt {
+			return 0, fmt.Errorf("%d does not fit in an int: %w", v, strconv.ErrRange)
+		}
+		return int(v), nil
+	case uint64:
+		if v > math.MaxInt {
+			return 0, fmt.Errorf("%d does not fit in an int: %w", v, strconv.ErrRange)
+		}
+		return int(v), nil
+	case string:
+		return ParseInt(v)
+	}
+	return 0, fmt.Errorf("cannot convert %T %v to an int", a, a)
+}
+
+// MustInt is like ParseInt but panics if s is not an integer.
+func MustInt(s string) int {
+	return must(ParseInt(s))
+}
+
+// MustInt64 is like ParseInt64 but panics if s is not an integer.
+func MustInt64(s string) int64 {
+	return must(ParseInt64(s))
+}
+
+// MustUint64 is like ParseUint64 but panics if s is not an unsigned integer.
+func MustUint64(s string) uint64 {
+	return must(ParseUint64(s))
+}
+
+// MustBig is like ParseBig but panics if s is not an integer.
+func MustBig(s string) *big.Int {
+	return must(ParseBig(s))
+}
+
+// MustHex is like ParseHex but panics if s is not a hexadecimal integer.
+func MustHex(s string) int {
+	return must(ParseHex(s))
+}
+
+// MustBinary is like ParseBinary but panics if s is not a binary integer.
+func MustBinary(s string) int {
+	return must(ParseBinary(s))
+}
+
+// must returns v, panicking if err is not nil.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,181 @@
+package eulerlib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect int
+		err    error
+	}{
+		{"42", 42, nil},
+		{"-7", -7, nil},
+		{"+7", 7, nil},
+		{"", 0, strconv.ErrSyntax},
+		{" 42", 0, strconv.ErrSyntax},
+		{"4x", 0, strconv.ErrSyntax},
+		{"99999999999999999999", 0, strconv.ErrRange},
+	}
+	for _, test := range tests {
+		got, err := ParseInt(test.input)
+		CheckTest(t, "convert.ParseInt", TTest{Name: strconv.Quote(test.input), Expect: test.expect}, got)
+		if !errors.Is(err, test.err) || (err == nil) != (test.err == nil) {
+			t.Errorf("ParseInt(%q): expected error %v, got %v", test.input, test.err, err)
+		}
+	}
+	_, err := ParseInt("4x")
+	CheckTest(t, "convert.ParseInt", TTest{Name: "message", Expect: `expected an integer, got "4x" in "4x"`}, err.Error())
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("expected a ParseError, got %T", err)
+	}
+}
+
+func TestParseWideInts(t *testing.T) {
+	i64, err := ParseInt64("-9223372036854775808")
+	CheckTest(t, "convert.ParseInt64", TTest{Name: "min", Expect: int64(-9223372036854775808)}, i64)
+	if err != nil {
+		t.Error(err)
+	}
+	u64, err := ParseUint64("18446744073709551615")
+	CheckTest(t, "convert.ParseUint64", TTest{Name: "max", Expect: uint64(18446744073709551615)}, u64)
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err := ParseUint64("-1"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("ParseUint64(-1): expected a syntax error, got %v", err)
+	}
+	if _, err := ParseUint64("18446744073709551616"); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("ParseUint64: expected a range error, got %v", err)
+	}
+
+	b, err := ParseBig("-123456789012345678901234567890")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	CheckTest(t, "convert.ParseBig", TTest{Name: "big", Expect: 0}, b.Cmp(want))
+	if _, err := ParseBig("12a"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("ParseBig(12a): expected a syntax error, got %v", err)
+	}
+}
+
+func TestParseBases(t *testing.T) {
+	tests := []struct {
+		name   string
+		parse  func(string) (int, error)
+		input  string
+		expect int
+		ok     bool
+	}{
+		{"hex", ParseHex, "ff", 255, true},
+		{"hex prefix", ParseHex, "0xFF", 255, true},
+		{"signed hex", ParseHex, "-0x10", -16, true},
+		{"bad hex", ParseHex, "fg", 0, false},
+		{"double sign", ParseHex, "-0x-1", 0, false},
+		{"binary", ParseBinary, "1011", 11, true},
+		{"binary prefix", ParseBinary, "0b101", 5, true},
+		{"signed binary", ParseBinary, "-11", -3, true},
+		{"bad binary", ParseBinary, "102", 0, false},
+		{"bare prefix", ParseBinary, "0b", 0, false},
+	}
+	for _, test := range tests {
+		got, err := test.parse(test.input)
+		CheckTest(t, "convert.ParseBase", TTest{Name: test.name, Expect: test.expect}, got)
+		CheckTest(t, "convert.ParseBase", TTest{Name: test.name + " ok", Expect: test.ok}, err == nil)
+	}
+}
+
+func TestToInt(t *testing.T) {
+	for _, v := range []any{7, int8(7), int16(7), int32(7), int64(7), uint8(7), uint16(7), uint32(7), uint(7), uint64(7), "7"} {
+		got, err := ToInt(v)
+		if err != nil || got != 7 {
+			t.Errorf("ToInt(%T 7) = %d, %v", v, got, err)
+		}
+	}
+	if _, err := ToInt(uint64(1 << 63)); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("expected a range error, got %v", err)
+	}
+	_, err := ToInt(7.5)
+	CheckTest(t, "convert.ToInt", TTest{Name: "float", Expect: "cannot convert float64 7.5 to an int"}, err.Error())
+}
+
+func TestMustInt(t *testing.T) {
+	CheckTest(t, "convert.MustInt", TTest{Name: "int", Expect: 12}, MustInt("12"))
+	CheckTest(t, "convert.MustInt64", TTest{Name: "int64", Expect: int64(12)}, MustInt64("12"))
+	CheckTest(t, "convert.MustUint64", TTest{Name: "uint64", Expect: uint64(12)}, MustUint64("12"))
+	CheckTest(t, "convert.MustBig", TTest{Name: "big", Expect: "12"}, MustBig("12").String())
+	CheckTest(t, "convert.MustHex", TTest{Name: "hex", Expect: 18}, MustHex("12"))
+	CheckTest(t, "convert.MustBinary", TTest{Name: "binary", Expect: 2}, MustBinary("10"))
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected MustInt to panic")
+		}
+	}()
+	MustInt("twelve")
+}
+
+// panicValue returns what f panics with, or nil.
+func panicValue(f func()) (r any) {
+	defer func() { r = recover() }()
+	f()
+	return nil
+}
+
+func TestConvStrict(t *testing.T) {
+	var bf bytes.Buffer
+	log, _ := NewLogger(&bf, LogOptions{})
+	lenient := Conv{Log: log}
+	CheckTest(t, "convert.StrToInt", TTest{Name: "lenient", Expect: 0}, lenient.StrToInt("x"))
+	CheckTest(t, "convert.GetIntArrFromDelimitedStr", TTest{Name: "lenient", Expect: []int{1, 0}}, lenient.GetIntArrFromDelimitedStr("1,x", ",", false))
+	CheckTest(t, "convert.Conv", TTest{Name: "logged", Expect: true}, strings.Contains(bf.String(), `level=WARN msg="conversion failed, using 0" err="col 3: expected an integer, got \"x\" in \"1,x\""`))
+
+	strict := ConvFrom(WithStrict(context.Background(), true))
+	CheckTest(t, "convert.StrToInt", TTest{Name: "strict good input", Expect: 5}, strict.StrToInt("5"))
+	tests := []struct {
+		name   string
+		f      func()
+		expect string
+	}{
+		{"StrToInt", func() { strict.StrToInt("x") }, `expected an integer, got "x" in "x"`},
+		{"GetStrArrAsIntArr", func() { strict.GetStrArrAsIntArr([]string{"1", "2.5"}) }, `expected an integer, got "2.5" in "2.5"`},
+		{"GetLinesAsIntArr", func() { strict.GetLinesAsIntArr([]string{"1 2", "3 y"}) }, `line 2, col 3: expected an integer, got "y" in "3 y"`},
+		{"GetLinesAsIntArr empty field", func() { strict.GetLinesAsIntArr([]string{"1  3"}) }, `line 1, col 3: expected an integer, got "" in "1  3"`},
+		{"GetIntArrFromDelimitedStr", func() { strict.GetIntArrFromDelimitedStr("1,x", ",", false) }, `col 3: expected an integer, got "x" in "1,x"`},
+	}
+	for _, test := range tests {
+		r := panicValue(test.f)
+		err, _ := r.(error)
+		if err == nil {
+			t.Errorf("%s: expected a panic with an error in strict mode, got %v", test.name, r)
+			continue
+		}
+		CheckTest(t, "convert.Strict", TTest{Name: test.name, Expect: test.expect}, err.Error())
+	}
+}
+
+func TestStrictFrom(t *testing.T) {
+	ctx := context.Background()
+	CheckTest(t, "convert.StrictFrom", TTest{Name: "unset", Expect: false}, StrictFrom(ctx))
+	CheckTest(t, "convert.StrictFrom", TTest{Name: "on", Expect: true}, StrictFrom(WithStrict(ctx, true)))
+	CheckTest(t, "convert.StrictFrom", TTest{Name: "off", Expect: false}, StrictFrom(WithStrict(WithStrict(ctx, true), false)))
+	//the package-level helpers are never strict
+	if r := panicValue(func() { StrToInt("x") }); r != nil {
+		t.Errorf("expected the package-level StrToInt not to panic, got %v", r)
+	}
+}
+
+func TestTestStrict(t *testing.T) {
+	for env, expect := range map[string]bool{"": true, "1": true, "0": false, "false": false, "junk": true} {
+		t.Setenv(StrictEnv, env)
+		CheckTest(t, "convert.TestStrict", TTest{Name: strconv.Quote(env), Expect: expect}, TestStrict())
+	}
+}
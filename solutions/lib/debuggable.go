@@ -1,8 +1,14 @@
 package eulerlib
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // Debuggable describes a type that can emit debug logging conditionally.
@@ -14,74 +20,253 @@ type Debuggable interface {
 	Logf(string, ...any)
 }
 
-var (
-	// debugger holds the singleton Debugger instance used throughout the
-	// package for conditional logging.
-	debugger *Debugger
-	// debuggerOnce ensures the debugger singleton is initialised only once.
-	debuggerOnce sync.Once
-)
+// debugger holds the global Debugger used by code that has no scoped one to
+// hand, such as solutions that do not take a context.
+var debugger atomic.Pointer[Debugger]
 
-// Debugger provides a simple flag-controlled wrapper around the standard
-// library logger for debug output.
+// Debugger is a levelled logger built on log/slog. Progress messages are
+// logged at info level, Log and Logf at debug level and the most verbose
+// output at LevelTrace, so SetDebug(true) reveals what the old boolean
+// debugger printed and SetDebug(false) hides it again. It is safe for
+// concurrent use. The zero value is ready to use and behaves like the
+// default global debugger.
 type Debugger struct {
 	Debuggable
-	debug bool
+	logger *slog.Logger
+	// level is shared by the Debugger and every scope derived from it.
+	level *slog.LevelVar
+	// once fills in the defaults for a Debugger not made by NewDebugger.
+	once sync.Once
+}
+
+// NewDebugger returns a Debugger that sends records at or above level to
+// handler. The handler should filter on the same level, as those made by
+// NewLogHandler do.
+func NewDebugger(handler slog.Handler, level *slog.LevelVar) *Debugger {
+	return &Debugger{logger: slog.New(handler), level: level}
+}
+
+// NewLogger returns a Debugger writing to w in the format and from the level
+// given by opts.
+func NewLogger(w io.Writer, opts LogOptions) (*Debugger, error) {
+	level := &slog.LevelVar{}
+	level.Set(opts.Level)
+	handler, err := NewLogHandler(w, opts.Format, level)
+	if err != nil {
+		return nil, err
+	}
+	return NewDebugger(handler, level), nil
 }
 
-// ResetDebugger clears the singleton debugger so it can be reinitialised.
+// defaults gives a zero Debugger the global debugger's handler and level:
+// text records at info level and above, written wherever the log package
+// writes.
+func (d *Debugger) defaults() {
+	d.once.Do(func() {
+		if d.level == nil {
+			d.level = &slog.LevelVar{}
+			d.level.Set(slog.LevelInfo)
+		}
+		if d.logger == nil {
+			handler, _ := NewLogHandler(stdLogWriter{}, LogText, d.level)
+			d.logger = slog.New(handler)
+		}
+	})
+}
+
+// ResetDebugger clears the global debugger so it can be reinitialised.
 // This is primarily intended for use in tests.
 func ResetDebugger() {
-	debuggerOnce = sync.Once{}
-	debugger = nil
+	debugger.Store(nil)
 }
 
-// SetDebug enables or disables debug logging for the Debugger.
+// SetDebug switches between debug and info level logging.
 func (d *Debugger) SetDebug(debug bool) {
-	d.debug = debug
+	d.defaults()
+	if debug {
+		d.level.Set(slog.LevelDebug)
+	} else {
+		d.level.Set(slog.LevelInfo)
+	}
 }
 
 // IsDebug reports whether debug logging is currently enabled.
 func (d *Debugger) IsDebug() bool {
-	return d.debug
+	return d.Enabled(slog.LevelDebug)
+}
+
+// SetLevel sets the lowest level that is logged.
+func (d *Debugger) SetLevel(level slog.Level) {
+	d.defaults()
+	d.level.Set(level)
 }
 
-// Log writes the supplied arguments using log.Println when debug logging is
-// enabled. When disabled, it performs no output.
+// Enabled reports whether records at level are logged, so expensive output
+// such as a grid dump can be skipped when it would be discarded.
+func (d *Debugger) Enabled(level slog.Level) bool {
+	d.defaults()
+	return level >= d.level.Level()
+}
+
+// Log writes the supplied arguments, spaced as by fmt.Println, as a debug
+// message. When debug logging is disabled it performs no output.
 func (d *Debugger) Log(args ...any) {
-	if d.debug {
-		log.Println(args...)
+	if d.IsDebug() {
+		d.logger.Debug(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
 	}
 }
 
-// Logf formats and writes a message using log.Printf when debug logging is
-// enabled. When disabled, it performs no output.
+// Logf formats and writes a debug message. When debug logging is disabled it
+// performs no output.
 func (d *Debugger) Logf(format string, args ...any) {
-	if d.debug {
-		log.Printf(format, args...)
+	if d.IsDebug() {
+		d.logger.Debug(fmt.Sprintf(format, args...))
 	}
 }
 
-// initDebugger constructs a new Debugger with debug output disabled.
+// Trace logs a message with slog key/value pairs at LevelTrace.
+func (d *Debugger) Trace(msg string, args ...any) {
+	d.defaults()
+	d.logger.Log(context.Background(), LevelTrace, msg, args...)
+}
+
+// Debug logs a message with slog key/value pairs at debug level.
+func (d *Debugger) Debug(msg string, args ...any) {
+	d.defaults()
+	d.logger.Debug(msg, args...)
+}
+
+// Info logs a message with slog key/value pairs at info level, the level used
+// for a solution's progress.
+func (d *Debugger) Info(msg string, args ...any) {
+	d.defaults()
+	d.logger.Info(msg, args...)
+}
+
+// With returns a Debugger that adds the given slog key/value pairs to every
+// record. It shares d's level.
+func (d *Debugger) With(args ...any) *Debugger {
+	d.defaults()
+	return &Debugger{logger: d.logger.With(args...), level: d.level}
+}
+
+// Scope returns a Debugger for one component, such as a data structure used
+// by several solutions, labelling its records with component=name.
+func (d *Debugger) Scope(name string) *Debugger {
+	return d.With("component", name)
+}
+
+// Slog returns the underlying slog.Logger.
+func (d *Debugger) Slog() *slog.Logger {
+	d.defaults()
+	return d.logger
+}
+
+// stdLogWriter writes to the standard library logger's current output, so
+// that the global debugger follows log.SetOutput.
+type stdLogWriter struct{}
+
+// Write writes p to log.Writer().
+func (stdLogWriter) Write(p []byte) (int, error) {
+	return log.Writer().Write(p)
+}
+
+// initDebugger constructs the default global debugger, a zero Debugger.
 func initDebugger() *Debugger {
-	return &Debugger{debug: false}
+	return &Debugger{}
 }
 
-// SetDebugger initialises (if necessary) and configures the global debugger
-// singleton, returning the instance for further use.
+// SetDebugger initialises (if necessary) and configures the global debugger,
+// returning the instance for further use.
 func SetDebugger(isDebug bool) *Debugger {
-	debuggerOnce.Do(func() {
-		debugger = initDebugger()
-	})
-	debugger.SetDebug(isDebug)
-	return debugger
+	d := GetDebugger()
+	d.SetDebug(isDebug)
+	return d
+}
+
+// SetLogger replaces the global debugger, for instance to route all output to
+// a file or silence it.
+func SetLogger(d *Debugger) {
+	debugger.Store(d)
 }
 
-// GetDebugger returns the global debugger singleton, initialising it on first
-// use.
+// GetDebugger returns the global debugger, initialising it on first use.
 func GetDebugger() *Debugger {
-	debuggerOnce.Do(func() {
-		debugger = initDebugger()
-	})
-	return debugger
+	if d := debugger.Load(); d != nil {
+		return d
+	}
+	debugger.CompareAndSwap(nil, initDebugger())
+	return debugger.Load()
+}
+
+// debuggerKey is the context key for the Debugger attached by WithDebugger.
+type debuggerKey struct{}
+
+// WithDebugger returns a copy of ctx carrying d, so that a problem run
+// alongside others logs through its own Debugger rather than the global one.
+func WithDebugger(ctx context.Context, d *Debugger) context.Context {
+	return context.WithValue(ctx, debuggerKey{}, d)
+}
+
+// DebuggerFrom returns the Debugger attached to ctx by WithDebugger, falling
+// back to the global debugger.
+func DebuggerFrom(ctx context.Context) *Debugger {
+	if d, ok := ctx.Value(debuggerKey{}).(*Debugger); ok && d != nil {
+		return d
+	}
+	return GetDebugger()
+}
+
+// LoggerProblem is implemented by solutions that log through a Debugger of
+// their own. TestProblem and the runner call SetLogger with the Debugger from
+// the run's context before solving, which is how solutions without a context
+// of their own are scoped to their problem. Embedding Logging implements it.
+type LoggerProblem interface {
+	SetLogger(*Debugger)
+}
+
+// Logging is embedded in a solution to give it a scoped Debugger, a Task to
+// report progress under and a Conv to convert its input with.
+type Logging struct {
+	log      *Debugger
+	progress *Task
+	strict   bool
+}
+
+// SetLogger sets the Debugger returned by Logger.
+func (m *Logging) SetLogger(d *Debugger) {
+	m.log = d
+}
+
+// Logger returns the Debugger set by SetLogger, or the global debugger if
+// there is none.
+func (m *Logging) Logger() *Debugger {
+	if m.log == nil {
+		return GetDebugger()
+	}
+	return m.log
+}
+
+// SetProgress sets the Task returned by Progress.
+func (m *Logging) SetProgress(t *Task) {
+	m.progress = t
+}
+
+// Progress returns the Task set by SetProgress, or a new detached one that is
+// never shown if there is none.
+func (m *Logging) Progress() *Task {
+	if m.progress == nil {
+		return NewTask("", 0)
+	}
+	return m.progress
+}
+
+// SetStrict sets whether the Conv returned by Conv is strict.
+func (m *Logging) SetStrict(on bool) {
+	m.strict = on
+}
+
+// Conv returns a Conv logging to Logger, strict if SetStrict made it so.
+func (m *Logging) Conv() Conv {
+	return Conv{Log: m.Logger(), Strict: m.strict}
 }
@@ -2,7 +2,11 @@ package eulerlib
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"io"
 	"log"
+	"log/slog"
 	"os"
 	"strings"
 	"testing"
@@ -83,6 +87,25 @@ func TestDebuggerNoOutput(t *testing.T) {
 	}
 }
 
+func TestDebuggerZeroValue(t *testing.T) {
+	var bf bytes.Buffer
+	log.SetOutput(&bf)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stdout)
+	})
+	//a Debugger declared without NewDebugger works as the old one did
+	var d Debugger
+	d.Log("hidden")
+	CheckTest(t, "Debugger", TTest{Name: "zero value off", Expect: false}, d.IsDebug())
+	d.SetDebug(true)
+	d.Logf("shown %d", 1)
+	d.Scope("part").Debug("scoped")
+	out := bf.String()
+	if strings.Contains(out, "hidden") || !strings.Contains(out, "shown 1") || !strings.Contains(out, "component=part") {
+		t.Errorf("expected only the messages logged after SetDebug(true), got %q", out)
+	}
+}
+
 func TestDebuggerLogfOutput(t *testing.T) {
 	var bf bytes.Buffer
 	log.SetOutput(&bf)
@@ -112,3 +135,86 @@ func TestDebuggerLogfNoOutput(t *testing.T) {
 		t.Errorf("Expected no formatted log output, got '%s'", bf.String())
 	}
 }
+
+func TestNewLogger(t *testing.T) {
+	var bf bytes.Buffer
+	d, err := NewLogger(&bf, LogOptions{Level: slog.LevelDebug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Logf("value %d", 3)
+	d.Trace("hidden trace")
+	d.SetDebug(false)
+	d.Log("hidden")
+	d.Info("progress", "count", 2)
+	out := bf.String()
+	for _, want := range []string{"level=DEBUG msg=\"value 3\"", "level=INFO msg=progress count=2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got %q", want, out)
+		}
+	}
+	if strings.Contains(out, "hidden") {
+		t.Errorf("Expected disabled levels to be dropped, got %q", out)
+	}
+}
+
+func TestDebuggerScope(t *testing.T) {
+	var bf bytes.Buffer
+	d, _ := NewLogger(&bf, LogOptions{Level: LevelTrace, Format: LogJSON})
+	scoped := d.With("problem", "day1-1").Scope("dial")
+	scoped.Trace("turn", "clicks", 5)
+	var record map[string]any
+	if err := json.Unmarshal(bf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %q: %v", bf.String(), err)
+	}
+	CheckTest(t, "debuggable.Scope", TTest{Name: "level", Expect: "TRACE"}, record["level"])
+	CheckTest(t, "debuggable.Scope", TTest{Name: "problem", Expect: "day1-1"}, record["problem"])
+	CheckTest(t, "debuggable.Scope", TTest{Name: "component", Expect: "dial"}, record["component"])
+	CheckTest(t, "debuggable.Scope", TTest{Name: "clicks", Expect: float64(5)}, record["clicks"])
+
+	//scopes share their parent's level
+	d.SetLevel(LevelOff)
+	if scoped.Enabled(slog.LevelError) {
+		t.Error("Expected the scope to follow its parent's level")
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	t.Cleanup(ResetDebugger)
+	d, _ := NewLogger(io.Discard, LogOptions{Level: LevelOff})
+	SetLogger(d)
+	if GetDebugger() != d {
+		t.Error("Expected SetLogger to replace the global debugger")
+	}
+}
+
+func TestDebuggerFrom(t *testing.T) {
+	ResetDebugger()
+	if DebuggerFrom(context.Background()) != GetDebugger() {
+		t.Error("Expected DebuggerFrom to fall back to the global debugger")
+	}
+	d, _ := NewLogger(io.Discard, LogOptions{})
+	if DebuggerFrom(WithDebugger(context.Background(), d)) != d {
+		t.Error("Expected DebuggerFrom to return the attached debugger")
+	}
+}
+
+// TLoggerProblem records the Debugger it is given.
+type TLoggerProblem struct {
+	TTestProblem
+	Logging
+}
+
+func TestSolveContextSetsLogger(t *testing.T) {
+	problem := &TLoggerProblem{}
+	if problem.Logger() != GetDebugger() {
+		t.Error("Expected Logging to fall back to the global debugger")
+	}
+	d, _ := NewLogger(io.Discard, LogOptions{})
+	if _, err := solveContext(WithDebugger(context.Background(), d), problem, false); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Logger() != d {
+		t.Error("Expected solveContext to pass on the context's debugger")
+	}
+}
@@ -0,0 +1,169 @@
+package eulerlib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TDirection represents a unit step in grid coordinates, with y increasing
+// downwards. Directions are values, so two with the same step are equal
+// however they were made.
+type TDirection struct {
+	X int
+	Y int
+}
+
+// The eight compass directions. Up is north.
+var (
+	UpDirection        = TDirection{X: 0, Y: -1}
+	UpRightDirection   = TDirection{X: 1, Y: -1}
+	RightDirection     = TDirection{X: 1, Y: 0}
+	DownRightDirection = TDirection{X: 1, Y: 1}
+	DownDirection      = TDirection{X: 0, Y: 1}
+	DownLeftDirection  = TDirection{X: -1, Y: 1}
+	LeftDirection      = TDirection{X: -1, Y: 0}
+	UpLeftDirection    = TDirection{X: -1, Y: -1}
+)
+
+// FailDirection is the zero direction, standing for no direction at all.
+var FailDirection = TDirection{}
+
+// DirectionToStr maps the compass directions to human-readable labels.
+var DirectionToStr = map[TDirection]string{
+	UpDirection:        "up",
+	UpRightDirection:   "up-right",
+	RightDirection:     "right",
+	DownRightDirection: "down-right",
+	DownDirection:      "down",
+	DownLeftDirection:  "down-left",
+	LeftDirection:      "left",
+	UpLeftDirection:    "up-left",
+}
+
+// AllDirections lists the four cardinal directions in clockwise order.
+var AllDirections = []TDirection{
+	UpDirection,
+	RightDirection,
+	DownDirection,
+	LeftDirection,
+}
+
+// CompassDirections lists all eight directions in clockwise order from up,
+// 45 degrees apart.
+var CompassDirections = []TDirection{
+	UpDirection,
+	UpRightDirection,
+	RightDirection,
+	DownRightDirection,
+	DownDirection,
+	DownLeftDirection,
+	LeftDirection,
+	UpLeftDirection,
+}
+
+// directionNames maps the letters and arrows puzzles use for directions to
+// the direction they stand for.
+var directionNames = map[string]TDirection{
+	"^": UpDirection, "v": DownDirection, "<": LeftDirection, ">": RightDirection,
+	"U": UpDirection, "D": DownDirection, "L": LeftDirection, "R": RightDirection,
+	"N": UpDirection, "S": DownDirection, "W": LeftDirection, "E": RightDirection,
+	"NE": UpRightDirection, "SE": DownRightDirection, "SW": DownLeftDirection, "NW": UpLeftDirection,
+}
+
+// ParseDirection reads a direction written as an arrow (^ v < >), a letter
+// (U D L R or N S E W), a compass point such as "NE", or a label from
+// DirectionToStr such as "down-left". Letters and labels may be in either
+// case.
+func ParseDirection(s string) (TDirection, error) {
+	if d, ok := directionNames[s]; ok {
+		return d, nil
+	}
+	if d, ok := directionNames[strings.ToUpper(s)]; ok {
+		return d, nil
+	}
+	for d, name := range DirectionToStr {
+		if strings.EqualFold(s, name) {
+			return d, nil
+		}
+	}
+	return FailDirection, &ParseError{Text: s, Msg: fmt.Sprintf("expected a direction, got %q", s)}
+}
+
+// DirectionOfRune returns the direction an arrow or direction letter such as
+// '^' or 'N' stands for, or false if r is not one.
+func DirectionOfRune(r rune) (TDirection, bool) {
+	d, err := ParseDirection(string(r))
+	return d, err == nil
+}
+
+// ParseTurn reads a relative turn, "L" or "R" in either case, as the number
+// of 45 degree steps Turn takes: -2 for a left turn and 2 for a right one.
+func ParseTurn(s string) (int, error) {
+	switch strings.ToUpper(s) {
+	case "L", "LEFT":
+		return -2, nil
+	case "R", "RIGHT":
+		return 2, nil
+	}
+	return 0, &ParseError{Text: s, Msg: fmt.Sprintf("expected L or R, got %q", s)}
+}
+
+// DirectionOf returns the compass direction of a unit step, or false if p is
+// not one.
+func DirectionOf(p Point) (TDirection, bool) {
+	d := TDirection{X: p.X, Y: p.Y}
+	_, ok := DirectionToStr[d]
+	return d, ok
+}
+
+// Point returns the step as an offset, so that p.Add(d.Point()) is the cell
+// one step from p.
+func (m TDirection) Point() Point {
+	return Point{m.X, m.Y}
+}
+
+// String returns the direction's label from DirectionToStr, or its step for
+// a direction that is not a compass point.
+func (m TDirection) String() string {
+	if name, ok := DirectionToStr[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("(%d,%d)", m.X, m.Y)
+}
+
+// IsDiagonal reports whether the direction moves along both axes.
+func (m TDirection) IsDiagonal() bool {
+	return m.X != 0 && m.Y != 0
+}
+
+// TurnRight returns the direction 90 degrees clockwise.
+func (m TDirection) TurnRight() TDirection {
+	return TDirection{X: -m.Y, Y: m.X}
+}
+
+// TurnLeft returns the direction 90 degrees anticlockwise.
+func (m TDirection) TurnLeft() TDirection {
+	return TDirection{X: m.Y, Y: -m.X}
+}
+
+// Reverse returns the opposite direction.
+func (m TDirection) Reverse() TDirection {
+	return TDirection{X: -m.X, Y: -m.Y}
+}
+
+// Turn returns the direction n steps of 45 degrees clockwise, or
+// anticlockwise if n is negative, so Turn(2) is TurnRight and Turn(4) is
+// Reverse. Only the compass directions can turn by an odd number of steps;
+// Turn panics if asked to turn any other direction that way.
+func (m TDirection) Turn(n int) TDirection {
+	n = ((n % 8) + 8) % 8
+	if n%2 == 0 {
+		return TDirection(m.Point().Rotate(n / 2))
+	}
+	for i, d := range CompassDirections {
+		if d == m {
+			return CompassDirections[(i+n)%8]
+		}
+	}
+	panic(fmt.Sprintf("cannot turn %v by 45 degrees", m))
+}
@@ -0,0 +1,104 @@
+package eulerlib
+
+import (
+	"testing"
+)
+
+func TestDirectionTurns(t *testing.T) {
+	for i, d := range CompassDirections {
+		CheckTest(t, "TDirection.Turn", TTest{Name: d.String() + " +1", Expect: CompassDirections[(i+1)%8]}, d.Turn(1))
+		CheckTest(t, "TDirection.Turn", TTest{Name: d.String() + " -3", Expect: CompassDirections[(i+5)%8]}, d.Turn(-3))
+		CheckTest(t, "TDirection.TurnRight", TTest{Name: d.String(), Expect: d.Turn(2)}, d.TurnRight())
+		CheckTest(t, "TDirection.TurnLeft", TTest{Name: d.String(), Expect: d.Turn(-2)}, d.TurnLeft())
+		CheckTest(t, "TDirection.Reverse", TTest{Name: d.String(), Expect: d.Turn(4)}, d.Reverse())
+		CheckTest(t, "TDirection.Turn", TTest{Name: d.String() + " full", Expect: d}, d.Turn(16))
+	}
+	CheckTest(t, "TDirection.TurnRight", TTest{Name: "up", Expect: RightDirection}, UpDirection.TurnRight())
+	CheckTest(t, "TDirection.Turn", TTest{Name: "left 45", Expect: UpLeftDirection}, UpDirection.Turn(-1))
+	CheckTest(t, "TDirection.IsDiagonal", TTest{Name: "down-right", Expect: true}, DownRightDirection.IsDiagonal())
+
+	//a fresh value is the same direction as the named one
+	CheckTest(t, "TDirection.String", TTest{Name: "constructed", Expect: "up"}, TDirection{X: 0, Y: -1}.String())
+	CheckTest(t, "TDirection.String", TTest{Name: "long step", Expect: "(2,0)"}, TDirection{X: 2, Y: 0}.String())
+	CheckTest(t, "TDirection.Turn", TTest{Name: "long step", Expect: TDirection{X: 0, Y: 2}}, TDirection{X: 2, Y: 0}.Turn(2))
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected Turn(1) of a long step to panic")
+		}
+	}()
+	TDirection{X: 2, Y: 0}.Turn(1)
+}
+
+func TestParseDirection(t *testing.T) {
+	tests := []struct {
+		in     string
+		expect TDirection
+	}{
+		{"^", UpDirection}, {"v", DownDirection}, {"<", LeftDirection}, {">", RightDirection},
+		{"U", UpDirection}, {"d", DownDirection}, {"L", LeftDirection}, {"r", RightDirection},
+		{"N", UpDirection}, {"S", DownDirection}, {"w", LeftDirection}, {"E", RightDirection},
+		{"NE", UpRightDirection}, {"sw", DownLeftDirection}, {"Down-Left", DownLeftDirection}, {"up", UpDirection},
+	}
+	for _, test := range tests {
+		d, err := ParseDirection(test.in)
+		if err != nil {
+			t.Errorf("ParseDirection(%q): %v", test.in, err)
+			continue
+		}
+		CheckTest(t, "ParseDirection", TTest{Name: test.in, Expect: test.expect}, d)
+	}
+	for _, d := range CompassDirections {
+		got, err := ParseDirection(d.String())
+		if err != nil || got != d {
+			t.Errorf("ParseDirection(%q) = %v, %v", d.String(), got, err)
+		}
+	}
+	_, err := ParseDirection("x")
+	CheckTest(t, "ParseDirection", TTest{Name: "bad", Expect: `expected a direction, got "x" in "x"`}, err.Error())
+	_, ok := DirectionOfRune('#')
+	CheckTest(t, "DirectionOfRune", TTest{Name: "not a direction", Expect: false}, ok)
+	d, _ := DirectionOfRune('>')
+	CheckTest(t, "DirectionOfRune", TTest{Name: "arrow", Expect: RightDirection}, d)
+}
+
+func TestParseTurn(t *testing.T) {
+	d := UpDirection
+	for _, s := range []string{"R", "r", "L", "L", "L"} {
+		n, err := ParseTurn(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d = d.Turn(n)
+	}
+	CheckTest(t, "ParseTurn", TTest{Name: "turns", Expect: LeftDirection}, d)
+	_, err := ParseTurn("U")
+	CheckTest(t, "ParseTurn", TTest{Name: "bad", Expect: `expected L or R, got "U" in "U"`}, err.Error())
+}
+
+func TestGridPositionDiagonalWalk(t *testing.T) {
+	g := &TGrid{}
+	g.Init()
+	g.ParseTable([]string{"S..", ".#.", "..."}, false)
+	gp := &TGridPosition{X: 0, Y: 0, Direction: DownRightDirection}
+	gp, err := g.WalkFromWithBlocker(gp, '#')
+	if err == nil || err.Error() != "blocked" {
+		t.Fatalf("expected to be blocked, got %v", err)
+	}
+	CheckTest(t, "TGrid.WalkFromWithBlocker", TTest{Name: "blocked", Expect: Point{0, 0}}, gp.Point())
+
+	gp.Turn(-1)
+	gp, err = g.WalkFromWithBlocker(gp, '#')
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "TGridPosition.Turn", TTest{Name: "right", Expect: "1, 0: right"}, gp.ToString())
+	gp.ChangeDirection(DownRightDirection)
+	gp, err = g.WalkFromWithBlocker(gp, '#')
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "TGrid.WalkFromWithBlocker", TTest{Name: "diagonal", Expect: "2, 1: down-right"}, gp.ToString())
+	_, err = g.WalkFromWithBlocker(gp, '#')
+	CheckTest(t, "TGrid.WalkFromWithBlocker", TTest{Name: "out of bounds", Expect: "out of bounds of grid"}, err.Error())
+}
@@ -0,0 +1,165 @@
+package eulerlib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ExamplesDir is the folder within a solution package holding extra example
+// inputs. Each examples/<name>.txt is paired with examples/<name>.answer
+// holding the expected answer.
+const ExamplesDir = "examples"
+
+// ExampleAnswerExt is the extension of the file holding an example's
+// expected answer.
+const ExampleAnswerExt = ".answer"
+
+// Example is a single worked example: an input and the answer it should
+// produce. An empty Expect means the answer is not known yet.
+type Example struct {
+	Name   string
+	Input  []string
+	Expect Answer
+}
+
+// ExampleProblem is implemented by solutions that list worked examples in
+// code rather than, or as well as, in the examples folder.
+type ExampleProblem interface {
+	Problem
+	Examples() []Example
+}
+
+// IntSolver matches the Solve method most solutions already have, letting
+// examples be run against them without any extra code.
+type IntSolver interface {
+	Solve(lines []string) int
+}
+
+// InputSolver is implemented by solutions that solve arbitrary input with a
+// structured answer. It is preferred over IntSolver when both are present.
+type InputSolver interface {
+	SolveInput(ctx context.Context, lines []string) (Answer, error)
+}
+
+// solveInput solves an example's input with whichever solver problem
+// implements.
+func solveInput(ctx context.Context, problem Problem, lines []string) (Answer, error) {
+	scopeProblem(ctx, problem)
+	switch s := problem.(type) {
+	case InputSolver:
+		return s.SolveInput(ctx, lines)
+	case IntSolver:
+		return IntAnswer(s.Solve(lines)), nil
+	}
+	return Answer{}, fmt.Errorf("%s has no Solve(lines []string) int or SolveInput method to run examples with", problem.GetProblemName())
+}
+
+// LoadExamples reads every examples/<name>.txt in dir, in name order, along
+// with its expected answer. An example without an answer file is returned
+// with an empty Expect. A missing examples folder yields no examples.
+func LoadExamples(dir string) ([]Example, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, ExamplesDir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+	examples := []Example{}
+	for _, path := range paths {
+		lines, err := ReadLines(path)
+		if err != nil {
+			return nil, err
+		}
+		example := Example{Name: strings.TrimSuffix(filepath.Base(path), ".txt"), Input: lines}
+		b, err := os.ReadFile(strings.TrimSuffix(path, ".txt") + ExampleAnswerExt)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		example.Expect = ParseAnswer(string(b))
+		examples = append(examples, example)
+	}
+	return examples, nil
+}
+
+// AnswerDiff describes how got differs from want. Multi-line answers are
+// compared line by line, marking wanted lines with "-" and generated lines
+// with "+"; anything else is shown as a single expected/got pair.
+func AnswerDiff(want, got Answer) string {
+	if want.Kind() != AnswerLines && got.Kind() != AnswerLines {
+		return fmt.Sprintf("expected %s, got %s", want, got)
+	}
+	wantLines, gotLines := want.Lines(), got.Lines()
+	var sb strings.Builder
+	for i := range max(len(wantLines), len(gotLines)) {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) && i < len(gotLines) && w == g {
+			fmt.Fprintf(&sb, "  %s\n", w)
+			continue
+		}
+		if i < len(wantLines) {
+			fmt.Fprintf(&sb, "- %s\n", w)
+		}
+		if i < len(gotLines) {
+			fmt.Fprintf(&sb, "+ %s\n", g)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// problemExamples gathers the examples listed by an ExampleProblem followed by
+// those in dir's examples folder.
+func problemExamples(problem Problem, dir string) ([]Example, error) {
+	examples := []Example{}
+	if ep, ok := problem.(ExampleProblem); ok {
+		examples = append(examples, ep.Examples()...)
+	}
+	if dir == "" {
+		return examples, nil
+	}
+	files, err := LoadExamples(dir)
+	return append(examples, files...), err
+}
+
+// testExamples runs each example as a subtest named after it, under ctx,
+// skipping examples whose answer is not known.
+func testExamples(ctx context.Context, problem Problem, t *testing.T, dir string, timeout time.Duration) {
+	examples, err := problemExamples(problem, dir)
+	if err != nil {
+		t.Errorf("Testing %s; loading examples failed: %v", problem.GetProblemName(), err)
+		return
+	}
+	for _, example := range examples {
+		t.Run(example.Name, func(t *testing.T) {
+			if example.Expect.IsEmpty() {
+				t.Skipf("no expected answer for example %s", example.Name)
+			}
+			ctx, cancel := WithTimeout(ctx, timeout)
+			defer cancel()
+			answer, err := generateContext(ctx, func() (Answer, error) {
+				return solveInput(ctx, problem, example.Input)
+			})
+			if err != nil {
+				t.Errorf("Testing %s; example %s failed: %v", problem.GetProblemName(), example.Name, err)
+				return
+			}
+			if !example.Expect.Equal(answer) {
+				t.Errorf("Testing %s; example %s:\n%s", problem.GetProblemName(), example.Name, AnswerDiff(example.Expect, answer))
+				return
+			}
+			ReportSuccess(problem.GetProblemName(), TTest{Name: "example " + example.Name})
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package eulerlib
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TLineCountProblem answers with the number of lines in its input and lists
+// one example in code.
+type TLineCountProblem struct {
+	Problem
+}
+
+func (m *TLineCountProblem) GetProblemName() string { return "Line Count Problem" }
+
+func (m *TLineCountProblem) GetAnswer() string { return "2" }
+
+func (m *TLineCountProblem) GenerateAnswer() string { return "2" }
+
+func (m *TLineCountProblem) Solve(lines []string) int { return len(lines) }
+
+func (m *TLineCountProblem) Examples() []Example {
+	return []Example{{Name: "three", Input: []string{"a", "b", "c"}, Expect: IntAnswer(3)}}
+}
+
+// TReverseProblem answers with its input lines reversed.
+type TReverseProblem struct {
+	Problem
+}
+
+func (m *TReverseProblem) GetProblemName() string { return "Reverse Problem" }
+
+func (m *TReverseProblem) Solve(lines []string) int { return -1 }
+
+func (m *TReverseProblem) SolveInput(ctx context.Context, lines []string) (Answer, error) {
+	reversed := make([]string, len(lines))
+	for i, line := range lines {
+		reversed[len(lines)-1-i] = line
+	}
+	return LinesAnswer(reversed), nil
+}
+
+func writeExample(t *testing.T, dir, name, input, answer string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ExamplesDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ExamplesDir, name+".txt"), []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if answer != "" {
+		if err := os.WriteFile(filepath.Join(dir, ExamplesDir, name+ExampleAnswerExt), []byte(answer), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadExamples(t *testing.T) {
+	dir := t.TempDir()
+	writeExample(t, dir, "b-second", "x\r\ny\r\n", "2\n")
+	writeExample(t, dir, "a-first", "x\n", "")
+	examples, err := LoadExamples(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, example := range examples {
+		names = append(names, example.Name)
+	}
+	CheckTest(t, "example.LoadExamples", TTest{Name: "sorted names", Expect: []string{"a-first", "b-second"}}, names)
+	CheckTest(t, "example.LoadExamples", TTest{Name: "no answer", Expect: true}, examples[0].Expect.IsEmpty())
+	CheckTest(t, "example.LoadExamples", TTest{Name: "input", Expect: []string{"x", "y"}}, examples[1].Input)
+	CheckTest(t, "example.LoadExamples", TTest{Name: "answer", Expect: true}, examples[1].Expect.Equal(IntAnswer(2)))
+
+	examples, err = LoadExamples(t.TempDir())
+	if err != nil || len(examples) != 0 {
+		t.Errorf("expected no examples without an examples folder, got %v (%v)", examples, err)
+	}
+}
+
+func TestAnswerDiff(t *testing.T) {
+	tests := []TTest{
+		{Name: "ints", Input: []Answer{IntAnswer(6), IntAnswer(7)}, Expect: "expected 6, got 7"},
+		{Name: "lines", Input: []Answer{LinesAnswer([]string{"#.", "..", "##"}), LinesAnswer([]string{"#.", "#."})}, Expect: "  #.\n- ..\n+ #.\n- ##"},
+	}
+	for _, test := range tests {
+		pair := test.Input.([]Answer)
+		CheckTest(t, "example.AnswerDiff", test, AnswerDiff(pair[0], pair[1]))
+	}
+}
+
+func TestSolveInput(t *testing.T) {
+	answer, err := solveInput(context.Background(), &TLineCountProblem{}, []string{"a", "b"})
+	CheckTest(t, "example.solveInput", TTest{Name: "int solver", Expect: "2"}, answer.String())
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	answer, _ = solveInput(context.Background(), &TReverseProblem{}, []string{"a", "b"})
+	CheckTest(t, "example.solveInput", TTest{Name: "input solver preferred", Expect: "b\na"}, answer.String())
+	_, err = solveInput(context.Background(), &TTestProblem{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "Test Problem has no Solve") {
+		t.Errorf("expected an error for a problem without a solver, got %v", err)
+	}
+}
+
+func TestTestProblemRunsExamples(t *testing.T) {
+	dir := t.TempDir()
+	writeExample(t, dir, "two", "a\nb\n", "2")
+	writeExample(t, dir, "unknown", "a\n", "")
+	examples, err := problemExamples(&TLineCountProblem{}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "example.problemExamples", TTest{Name: "count", Expect: 3}, len(examples))
+	testProblem(&TLineCountProblem{}, t, time.Second, InputSearch{Dir: dir})
+}
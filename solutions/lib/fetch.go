@@ -0,0 +1,321 @@
+package eulerlib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SessionEnv names the environment variable holding the adventofcode.com
+// session cookie used to download inputs.
+const SessionEnv = "AOC_SESSION"
+
+// DefaultFetchURL is the site inputs and puzzles are downloaded from.
+const DefaultFetchURL = "https://adventofcode.com"
+
+// DefaultFetchInterval is the least time a Fetcher leaves between requests.
+const DefaultFetchInterval = 3 * time.Second
+
+// PuzzleFile is the name the puzzle description is cached under, alongside
+// the input files.
+const PuzzleFile = "puzzle.html"
+
+// fetchUserAgent identifies the client to the site, as its operators ask.
+const fetchUserAgent = "github.com/nfitbh72/aoc2025 eulerlib"
+
+// ErrNoSession is returned when a download is attempted without a session
+// token.
+var ErrNoSession = errors.New("no session token: set " + SessionEnv + " or write it to " + filepath.Join("<config dir>", "aoc", "session"))
+
+// ErrNoExample is returned when a puzzle description has no example block.
+var ErrNoExample = errors.New("no example found in the puzzle description")
+
+// SessionFile returns the file LoadSession reads the session token from when
+// AOC_SESSION is not set: "aoc/session" in the user's config directory.
+func SessionFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aoc", "session")
+}
+
+// LoadSession returns the session token from AOC_SESSION or SessionFile, or
+// ErrNoSession if neither has one.
+func LoadSession() (string, error) {
+	if session := strings.TrimSpace(os.Getenv(SessionEnv)); session != "" {
+		return session, nil
+	}
+	if file := SessionFile(); file != "" {
+		b, err := os.ReadFile(file)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		if session := strings.TrimSpace(string(b)); session != "" {
+			return session, nil
+		}
+	}
+	return "", ErrNoSession
+}
+
+// FetchError reports a request the site refused.
+type FetchError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// RetryAfter is how long the site asked us to wait, if it said.
+	RetryAfter time.Duration
+	// Message is the start of the response body, which usually says why.
+	Message string
+}
+
+// Error describes the failed request.
+func (e *FetchError) Error() string {
+	msg := fmt.Sprintf("%s %s: %s", e.Method, e.URL, http.StatusText(e.StatusCode))
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		msg += " (is the puzzle unlocked yet?)"
+	case e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnauthorized:
+		msg += " (is the session token valid?)"
+	case e.RetryAfter > 0:
+		msg += fmt.Sprintf(" (retry after %s)", e.RetryAfter)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Fetcher downloads puzzle inputs, descriptions and examples, caching each
+// under CacheDir as <year>/day<day>/<file> so that it is only ever downloaded
+// once. Requests are made one at a time and at least Interval apart, and a
+// Retry-After from the site pushes the next request back further. A Fetcher
+// is safe for concurrent use.
+type Fetcher struct {
+	// BaseURL is the site to download from, DefaultFetchURL unless a test
+	// points it at a stand-in server.
+	BaseURL  string
+	Session  string
+	CacheDir string
+	Interval time.Duration
+	Client   *http.Client
+
+	mu sync.Mutex
+	// next is the earliest time the next request may be made.
+	next time.Time
+}
+
+// NewFetcher returns a Fetcher using session that caches in InputCacheDir.
+func NewFetcher(session string) *Fetcher {
+	return &Fetcher{
+		BaseURL:  DefaultFetchURL,
+		Session:  session,
+		CacheDir: InputCacheDir(),
+		Interval: DefaultFetchInterval,
+		Client:   http.DefaultClient,
+	}
+}
+
+// CachePath returns where a file for a year and day is cached. It is the
+// same location InputSearch looks in.
+func (m *Fetcher) CachePath(year, day int, filename string) string {
+	return inputCachePath(m.CacheDir, year, day, filename)
+}
+
+// Input returns the path of the cached input for a year and day, downloading
+// it first if it is not cached.
+func (m *Fetcher) Input(ctx context.Context, year, day int) (string, error) {
+	return m.cached(ctx, year, day, DefaultInput, func() ([]byte, error) {
+		return m.get(ctx, fmt.Sprintf("/%d/day/%d/input", year, day))
+	})
+}
+
+// Puzzle returns the path of the cached puzzle description for a year and
+// day, downloading it first if it is not cached. Part 2 is only in the page
+// once part 1 is solved, so delete the cached page to pick it up.
+func (m *Fetcher) Puzzle(ctx context.Context, year, day int) (string, error) {
+	return m.cached(ctx, year, day, PuzzleFile, func() ([]byte, error) {
+		return m.get(ctx, fmt.Sprintf("/%d/day/%d", year, day))
+	})
+}
+
+// Example returns the path of the cached example input for a year and day:
+// the first example of the puzzle description, which is fetched if need be.
+func (m *Fetcher) Example(ctx context.Context, year, day int) (string, error) {
+	puzzle, err := m.Puzzle(ctx, year, day)
+	if err != nil {
+		return "", err
+	}
+	return m.cached(ctx, year, day, DefaultShortInput, func() ([]byte, error) {
+		page, err := os.ReadFile(puzzle)
+		if err != nil {
+			return nil, err
+		}
+		examples := ParsePuzzleExamples(string(page))
+		if len(examples) == 0 || examples[0].Input == nil {
+			return nil, fmt.Errorf("%s: %w", puzzle, ErrNoExample)
+		}
+		return []byte(strings.Join(examples[0].Input, "\n")), nil
+	})
+}
+
+// Fetch returns the path of a cached file by name: DefaultInput,
+// DefaultShortInput or PuzzleFile.
+func (m *Fetcher) Fetch(ctx context.Context, year, day int, filename string) (string, error) {
+	switch filename {
+	case DefaultInput:
+		return m.Input(ctx, year, day)
+	case DefaultShortInput:
+		return m.Example(ctx, year, day)
+	case PuzzleFile:
+		return m.Puzzle(ctx, year, day)
+	}
+	return "", fmt.Errorf("cannot fetch %s: only %s, %s and %s are published", filename, DefaultInput, DefaultShortInput, PuzzleFile)
+}
+
+// cached returns the cache path of filename, first writing what download
+// returns to it if it does not exist yet.
+func (m *Fetcher) cached(ctx context.Context, year, day int, filename string, download func() ([]byte, error)) (string, error) {
+	if m.CacheDir == "" {
+		return "", errors.New("no input cache directory: set " + InputCacheEnv)
+	}
+	path := m.CachePath(year, day, filename)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	b, err := download()
+	if err != nil {
+		return "", err
+	}
+	return path, writeFileAtomic(path, b)
+}
+
+// get downloads a page of the site once the rate limit allows.
+func (m *Fetcher) get(ctx context.Context, page string) ([]byte, error) {
+	return m.do(ctx, http.MethodGet, page, nil)
+}
+
+// do makes a request to the site once the rate limit allows, posting form
+// when it is not nil, and returns the body of a successful response.
+func (m *Fetcher) do(ctx context.Context, method, page string, form url.Values) ([]byte, error) {
+	if m.Session == "" {
+		return nil, ErrNoSession
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := sleepContext(ctx, time.Until(m.next)); err != nil {
+		return nil, err
+	}
+	m.next = time.Now().Add(m.Interval)
+
+	target := strings.TrimSuffix(m.BaseURL, "/") + page
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: m.Session})
+	req.Header.Set("User-Agent", fetchUserAgent)
+	client := m.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		ferr := &FetchError{Method: method, URL: target, StatusCode: resp.StatusCode, Message: firstLine(string(b))}
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			ferr.RetryAfter = time.Duration(secs) * time.Second
+			m.next = time.Now().Add(max(ferr.RetryAfter, m.Interval))
+		}
+		return nil, ferr
+	}
+	return b, nil
+}
+
+// sleepContext waits for d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// firstLine returns the first non-blank line of s, shortened for an error
+// message.
+func firstLine(s string) string {
+	for line := range strings.SplitSeq(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			if len(line) > 200 {
+				line = line[:200] + "..."
+			}
+			return line
+		}
+	}
+	return ""
+}
+
+// writeFileAtomic writes b to path through a temporary file, so an
+// interrupted download never leaves a partial file in the cache.
+func writeFileAtomic(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// inputFetcher downloads inputs missing from every search location when set
+// by SetInputFetcher.
+var inputFetcher *Fetcher
+
+// SetInputFetcher makes LoadInput and friends download an input or example
+// into the cache when it is not found anywhere else. The cache is searched
+// first, so inputs fetched once are still found offline. A nil Fetcher turns
+// downloading off again.
+func SetInputFetcher(f *Fetcher) {
+	inputFetcher = f
+}
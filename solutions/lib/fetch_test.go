@@ -0,0 +1,198 @@
+package eulerlib
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSite stands in for adventofcode.com, serving one input and puzzle page
+// per day and counting the requests it receives.
+type fakeSite struct {
+	mu       sync.Mutex
+	requests map[string]int
+	// retryAfter, when set, makes every request fail with 429.
+	retryAfter string
+}
+
+const fakePuzzle = `<article class="day-desc"><h2>--- Day 3: Test ---</h2>
+<p>For example:</p>
+<pre><code>987654321111111
+811111111111119
+<em>2</em> &lt; 3
+</code></pre>
+<p>The answer is <code><em>357</em></code>.</p>
+<pre><code>ignored</code></pre></article>`
+
+func (m *fakeSite) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	m.requests[r.URL.Path]++
+	m.mu.Unlock()
+	if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		return
+	}
+	if m.retryAfter != "" {
+		w.Header().Set("Retry-After", m.retryAfter)
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+		return
+	}
+	switch r.URL.Path {
+	case "/2025/day/3/input", "/2025/day/4/input":
+		w.Write([]byte("input for " + r.URL.Path + "\n"))
+	case "/2025/day/3":
+		w.Write([]byte(fakePuzzle))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (m *fakeSite) count(path string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests[path]
+}
+
+// newFakeFetcher returns a Fetcher pointed at a fake site, caching in a
+// temporary directory.
+func newFakeFetcher(t *testing.T, session string) (*Fetcher, *fakeSite) {
+	site := &fakeSite{requests: map[string]int{}}
+	server := httptest.NewServer(site)
+	t.Cleanup(server.Close)
+	f := NewFetcher(session)
+	f.BaseURL = server.URL
+	f.CacheDir = t.TempDir()
+	f.Interval = 0
+	f.Client = server.Client()
+	return f, site
+}
+
+func TestFetcherInputCached(t *testing.T) {
+	f, site := newFakeFetcher(t, "secret")
+	ctx := context.Background()
+	path, err := f.Input(ctx, 2025, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "fetch.Input", TTest{Name: "cache path", Expect: filepath.Join(f.CacheDir, "2025", "day3", "input.txt")}, path)
+	lines, _ := ReadLines(path)
+	CheckTest(t, "fetch.Input", TTest{Name: "content", Expect: []string{"input for /2025/day/3/input"}}, lines)
+
+	if _, err := f.Input(ctx, 2025, 3); err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "fetch.Input", TTest{Name: "downloaded once", Expect: 1}, site.count("/2025/day/3/input"))
+}
+
+func TestFetcherExample(t *testing.T) {
+	f, site := newFakeFetcher(t, "secret")
+	path, err := f.Example(context.Background(), 2025, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, _ := ReadLines(path)
+	CheckTest(t, "fetch.Example", TTest{Name: "first code block", Expect: []string{"987654321111111", "811111111111119", "2 < 3"}}, lines)
+	if _, err := os.Stat(f.CachePath(2025, 3, PuzzleFile)); err != nil {
+		t.Errorf("expected the puzzle page to be cached: %v", err)
+	}
+	if _, err := f.Example(context.Background(), 2025, 3); err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "fetch.Example", TTest{Name: "page downloaded once", Expect: 1}, site.count("/2025/day/3"))
+}
+
+func TestFetcherErrors(t *testing.T) {
+	ctx := context.Background()
+	f, _ := newFakeFetcher(t, "")
+	if _, err := f.Input(ctx, 2025, 3); !errors.Is(err, ErrNoSession) {
+		t.Errorf("expected ErrNoSession, got %v", err)
+	}
+
+	f, _ = newFakeFetcher(t, "stale")
+	_, err := f.Input(ctx, 2025, 3)
+	var ferr *FetchError
+	if !errors.As(err, &ferr) {
+		t.Fatalf("expected a FetchError, got %v", err)
+	}
+	CheckTest(t, "fetch.Input", TTest{Name: "bad session status", Expect: http.StatusBadRequest}, ferr.StatusCode)
+
+	f, _ = newFakeFetcher(t, "secret")
+	_, err = f.Input(ctx, 2025, 25)
+	if !errors.As(err, &ferr) || ferr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a 404 FetchError, got %v", err)
+	}
+	if _, err := os.Stat(f.CachePath(2025, 25, DefaultInput)); err == nil {
+		t.Error("expected a failed download not to be cached")
+	}
+	if _, err := f.Fetch(ctx, 2025, 3, "notes.txt"); err == nil {
+		t.Error("expected an error fetching an unpublished file")
+	}
+}
+
+func TestFetcherRateLimit(t *testing.T) {
+	f, site := newFakeFetcher(t, "secret")
+	f.Interval = 50 * time.Millisecond
+	ctx := context.Background()
+	start := time.Now()
+	for _, day := range []int{3, 4} {
+		if _, err := f.Input(ctx, 2025, day); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if took := time.Since(start); took < f.Interval {
+		t.Errorf("expected two requests to take at least %s, took %s", f.Interval, took)
+	}
+
+	f, site = newFakeFetcher(t, "secret")
+	site.retryAfter = "60"
+	_, err := f.Input(ctx, 2025, 3)
+	var ferr *FetchError
+	if !errors.As(err, &ferr) {
+		t.Fatalf("expected a FetchError, got %v", err)
+	}
+	CheckTest(t, "fetch.Input", TTest{Name: "retry after", Expect: time.Minute}, ferr.RetryAfter)
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := f.Input(ctx, 2025, 4); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the next request to wait out Retry-After, got %v", err)
+	}
+	CheckTest(t, "fetch.Input", TTest{Name: "no request while waiting", Expect: 0}, site.count("/2025/day/4/input"))
+}
+
+func TestLoadInputFetches(t *testing.T) {
+	f, site := newFakeFetcher(t, "secret")
+	t.Setenv(InputCacheEnv, f.CacheDir)
+	search := inputSearchForDir(filepath.Join(t.TempDir(), "day3-1"))
+	if _, err := search.Load("input.txt"); err == nil {
+		t.Fatal("expected no input before a fetcher is set")
+	}
+
+	SetInputFetcher(f)
+	defer SetInputFetcher(nil)
+	lines, err := search.Load("input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "input.Load", TTest{Name: "fetched", Expect: []string{"input for /2025/day/3/input"}}, lines)
+
+	//offline, the cached copy is found before the fetcher is asked
+	f.BaseURL = "http://127.0.0.1:0"
+	lines, err = search.Load("input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "input.Load", TTest{Name: "cached", Expect: []string{"input for /2025/day/3/input"}}, lines)
+	CheckTest(t, "input.Load", TTest{Name: "downloaded once", Expect: 1}, site.count("/2025/day/3/input"))
+
+	_, err = inputSearchForDir(filepath.Join(t.TempDir(), "day9-1")).Load("input.txt")
+	var notFound *InputNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected a failed fetch to report InputNotFoundError, got %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+package eulerlib
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// CellGrid is a grid of byte cells, such as a CompactGrid or a SparseGrid,
+// for the flood fill and region functions to share. They take the Rect of
+// cells to work in, as a SparseGrid has no bounds of its own.
+type CellGrid interface {
+	GetAt(p Point) byte
+	SetAt(p Point, value byte)
+}
+
+// Connectivity is whether cells connect only through their 4 edges or
+// through their corners too, making 8 neighbours.
+type Connectivity int
+
+const (
+	Connect4 Connectivity = 4
+	Connect8 Connectivity = 8
+)
+
+// neighbours iterates over the neighbours of p in area with this
+// connectivity.
+func (m Connectivity) neighbours(p Point, area Rect) iter.Seq[Point] {
+	if m == Connect8 {
+		return p.Neighbours8In(area)
+	}
+	return p.Neighbours4In(area)
+}
+
+// FloodFill sets the cells of area connected to start that have start's
+// value to fill, returning how many it changed. It uses a queue rather than
+// recursion, so a region can be as large as the grid.
+func FloodFill(g CellGrid, area Rect, start Point, fill byte, conn Connectivity) int {
+	if !area.Contains(start) {
+		return 0
+	}
+	target := g.GetAt(start)
+	if target == fill {
+		return 0
+	}
+	//filled cells no longer match target, so they mark themselves visited
+	g.SetAt(start, fill)
+	queue := []Point{start}
+	for i := 0; i < len(queue); i++ {
+		for n := range conn.neighbours(queue[i], area) {
+			if g.GetAt(n) == target {
+				g.SetAt(n, fill)
+				queue = append(queue, n)
+			}
+		}
+	}
+	return len(queue)
+}
+
+// FillOutside sets to fill every cell of area that can be reached from its
+// edge without crossing a cell whose value is in boundary, returning how
+// many cells it changed.
+func FillOutside(g CellGrid, area Rect, fill byte, boundary map[byte]bool, conn Connectivity) int {
+	count := 0
+	for p := range outside(g, area, boundary, conn).all() {
+		if g.GetAt(p) != fill {
+			g.SetAt(p, fill)
+			count++
+		}
+	}
+	return count
+}
+
+// FillEnclosed sets to fill every cell of area that is not in boundary and
+// cannot be reached from the edge of area without crossing a cell that is,
+// returning how many cells it changed. Unlike FillEnclosedArea it finds the
+// outside by flood filling from the edge, so boundaries running along a row
+// do not confuse it.
+func FillEnclosed(g CellGrid, area Rect, fill byte, boundary map[byte]bool, conn Connectivity) int {
+	out := outside(g, area, boundary, conn)
+	count := 0
+	for y := area.Min.Y; y <= area.Max.Y; y++ {
+		for x := area.Min.X; x <= area.Max.X; x++ {
+			p := Point{x, y}
+			if v := g.GetAt(p); !boundary[v] && v != fill && !out.has(p) {
+				g.SetAt(p, fill)
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// outside returns the cells of area reachable from its edge without
+// crossing a boundary cell.
+func outside(g CellGrid, area Rect, boundary map[byte]bool, conn Connectivity) *cellSet {
+	seen := newCellSet(area)
+	var queue []Point
+	visit := func(p Point) {
+		if !seen.has(p) && !boundary[g.GetAt(p)] {
+			seen.add(p)
+			queue = append(queue, p)
+		}
+	}
+	for x := area.Min.X; x <= area.Max.X; x++ {
+		visit(Point{x, area.Min.Y})
+		visit(Point{x, area.Max.Y})
+	}
+	for y := area.Min.Y; y <= area.Max.Y; y++ {
+		visit(Point{area.Min.X, y})
+		visit(Point{area.Max.X, y})
+	}
+	for i := 0; i < len(queue); i++ {
+		for n := range conn.neighbours(queue[i], area) {
+			visit(n)
+		}
+	}
+	return seen
+}
+
+// Region is a connected group of cells with the same value.
+type Region struct {
+	// Label is the region's index in RegionMap.Regions.
+	Label int
+	Value byte
+	// Start is the region's first cell in reading order.
+	Start Point
+	// Area is the number of cells in the region.
+	Area int
+	// Perimeter is the number of cell edges between the region and the
+	// cells around it, including cells outside the area searched.
+	Perimeter int
+	// Sides is the number of straight sides of the region's outline,
+	// counting the outlines of any holes in it.
+	Sides int
+}
+
+// RegionMap is the connected regions of a grid and which region each cell
+// belongs to.
+type RegionMap struct {
+	Bounds  Rect
+	Regions []Region
+	// labels holds the label of each cell of Bounds, offset by Bounds.Min.
+	labels *Grid[int]
+}
+
+// LabelAt returns the label of the region p belongs to, or -1 if p is
+// outside the area labelled.
+func (m *RegionMap) LabelAt(p Point) int {
+	if !m.Bounds.Contains(p) {
+		return -1
+	}
+	return m.labels.At(p.X-m.Bounds.Min.X, p.Y-m.Bounds.Min.Y)
+}
+
+// RegionAt returns the region p belongs to, or false if p is outside the
+// area labelled.
+func (m *RegionMap) RegionAt(p Point) (Region, bool) {
+	label := m.LabelAt(p)
+	if label < 0 {
+		return Region{}, false
+	}
+	return m.Regions[label], true
+}
+
+// LabelRegions splits the cells of area into connected regions of equal
+// value, measuring each one's area, perimeter and sides. Perimeter and sides
+// are those of the region's cells as squares, whatever the connectivity.
+func LabelRegions(g CellGrid, area Rect, conn Connectivity) *RegionMap {
+	width, height := area.Max.X-area.Min.X+1, area.Max.Y-area.Min.Y+1
+	m := &RegionMap{Bounds: area, labels: NewGridFilled(width, height, -1)}
+	for y := area.Min.Y; y <= area.Max.Y; y++ {
+		for x := area.Min.X; x <= area.Max.X; x++ {
+			if start := (Point{x, y}); m.LabelAt(start) < 0 {
+				m.Regions = append(m.Regions, m.label(g, start, len(m.Regions), conn))
+			}
+		}
+	}
+	return m
+}
+
+// label labels the region containing start and measures it.
+func (m *RegionMap) label(g CellGrid, start Point, label int, conn Connectivity) Region {
+	r := Region{Label: label, Value: g.GetAt(start), Start: start}
+	origin := m.Bounds.Min
+	m.labels.Put(start.X-origin.X, start.Y-origin.Y, label)
+	queue := []Point{start}
+	for i := 0; i < len(queue); i++ {
+		for n := range conn.neighbours(queue[i], m.Bounds) {
+			if m.LabelAt(n) < 0 && g.GetAt(n) == r.Value {
+				m.labels.Put(n.X-origin.X, n.Y-origin.Y, label)
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	in := func(p Point) bool { return m.LabelAt(p) == label }
+	r.Area = len(queue)
+	for _, p := range queue {
+		for i, d := range neighbours4 {
+			if !in(p.Add(d)) {
+				r.Perimeter++
+			}
+			//a region has as many sides as corners: count those at this cell
+			//between this edge and the next one clockwise
+			next := neighbours4[(i+1)%4]
+			a, b, diag := in(p.Add(d)), in(p.Add(next)), in(p.Add(d).Add(next))
+			if !a && !b || a && b && !diag {
+				r.Sides++
+			}
+		}
+	}
+	return r
+}
+
+// cellSet is a set of the cells of a Rect, one bit per cell.
+type cellSet struct {
+	area  Rect
+	width int
+	bits  []uint64
+}
+
+// newCellSet returns an empty set of cells of area.
+func newCellSet(area Rect) *cellSet {
+	width := area.Max.X - area.Min.X + 1
+	cells := width * (area.Max.Y - area.Min.Y + 1)
+	return &cellSet{area: area, width: width, bits: make([]uint64, (cells+63)/64)}
+}
+
+// index returns the bit number for p.
+func (m *cellSet) index(p Point) int {
+	return (p.Y-m.area.Min.Y)*m.width + p.X - m.area.Min.X
+}
+
+func (m *cellSet) has(p Point) bool {
+	i := m.index(p)
+	return m.bits[i/64]&(1<<(i%64)) != 0
+}
+
+func (m *cellSet) add(p Point) {
+	i := m.index(p)
+	m.bits[i/64] |= 1 << (i % 64)
+}
+
+// all iterates over the cells in the set in reading order.
+func (m *cellSet) all() iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for i, word := range m.bits {
+			for ; word != 0; word &= word - 1 {
+				j := i*64 + bits.TrailingZeros64(word)
+				p := Point{m.area.Min.X + j%m.width, m.area.Min.Y + j/m.width}
+				if !yield(p) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package eulerlib
+
+import (
+	"testing"
+)
+
+// sparseFromLines returns a SparseGrid holding lines, with '.' left empty,
+// and the Rect they cover.
+func sparseFromLines(lines []string) (*SparseGrid, Rect) {
+	g := NewSparseGrid()
+	for y, line := range lines {
+		for x := 0; x < len(line); x++ {
+			if line[x] != '.' {
+				g.Set(x, y, line[x])
+			}
+		}
+	}
+	return g, Rect{Max: Point{len(lines[0]) - 1, len(lines) - 1}}
+}
+
+func TestFloodFill(t *testing.T) {
+	lines := []string{
+		"#####",
+		"#..##",
+		"###.#",
+		"#####",
+	}
+	g, area := sparseFromLines(lines)
+	CheckTest(t, "FloodFill", TTest{Name: "4-connected", Expect: 2}, FloodFill(g, area, Point{1, 1}, 'o', Connect4))
+	CheckTest(t, "FloodFill", TTest{Name: "4-connected grid", Expect: "#####\n#oo##\n###.#\n#####\n"}, g.ToString(0, 4, 0, 3))
+	g, area = sparseFromLines(lines)
+	CheckTest(t, "FloodFill", TTest{Name: "8-connected", Expect: 3}, FloodFill(g, area, Point{1, 1}, 'x', Connect8))
+	CheckTest(t, "FloodFill", TTest{Name: "same value", Expect: 0}, FloodFill(g, area, Point{1, 1}, 'x', Connect8))
+	CheckTest(t, "FloodFill", TTest{Name: "outside area", Expect: 0}, FloodFill(g, area, Point{9, 9}, 'x', Connect8))
+
+	//a fill over a whole large grid does not recurse
+	cg := NewCompactGrid(1000, 1000)
+	CheckTest(t, "FloodFill", TTest{Name: "large", Expect: 1000 * 1000}, FloodFill(cg, Rect{Max: Point{999, 999}}, Point{500, 500}, 1, Connect4))
+}
+
+func TestFillEnclosed(t *testing.T) {
+	//a spiral, whose boundary runs along rows
+	lines := []string{
+		"..........",
+		".#####....",
+		".#...#....",
+		".#.#.####.",
+		".#.#....#.",
+		".#.######.",
+		".#........",
+		".#########",
+	}
+	boundary := map[byte]bool{'#': true}
+
+	g, area := sparseFromLines(lines)
+	CheckTest(t, "FillEnclosed", TTest{Name: "open", Expect: 0}, FillEnclosed(g, area, 'o', boundary, Connect4))
+
+	lines[6] = ".#.......#"
+	lines[5] = ".#.#######"
+	lines[3] = ".#.#.#####"
+	lines[4] = ".#.#.....#"
+	g, area = sparseFromLines(lines)
+	CheckTest(t, "FillEnclosed", TTest{Name: "closed", Expect: 19}, FillEnclosed(g, area, 'o', boundary, Connect4))
+	CheckTest(t, "FillEnclosed", TTest{Name: "closed grid", Expect: "" +
+		"..........\n" +
+		".#####....\n" +
+		".#ooo#....\n" +
+		".#o#o#####\n" +
+		".#o#ooooo#\n" +
+		".#o#######\n" +
+		".#ooooooo#\n" +
+		".#########\n"}, g.ToString(0, 9, 0, 7))
+
+	g, area = sparseFromLines(lines)
+	CheckTest(t, "FillOutside", TTest{Name: "outside", Expect: 25}, FillOutside(g, area, 'x', boundary, Connect4))
+	CheckTest(t, "FillOutside", TTest{Name: "enclosed left", Expect: byte(0)}, g.Get(2, 2))
+	CheckTest(t, "FillOutside", TTest{Name: "corner", Expect: byte('x')}, g.Get(9, 0))
+
+	//with 8-connectivity the outside leaks through a diagonal gap
+	cg := NewCompactGrid(4, 4)
+	for _, p := range []Point{{1, 0}, {0, 1}, {2, 1}, {1, 2}} {
+		cg.SetAt(p, 1)
+	}
+	b := map[byte]bool{1: true}
+	CheckTest(t, "FillEnclosed", TTest{Name: "diamond 8", Expect: 0}, FillEnclosed(cg, Rect{Max: Point{3, 3}}, 2, b, Connect8))
+	CheckTest(t, "FillEnclosed", TTest{Name: "diamond 4", Expect: 1}, FillEnclosed(cg, Rect{Max: Point{3, 3}}, 2, b, Connect4))
+	CheckTest(t, "FillEnclosed", TTest{Name: "diamond centre", Expect: byte(2)}, cg.GetAt(Point{1, 1}))
+}
+
+// fencePrices returns the 2024 day 12 prices: the sum of area times
+// perimeter, and of area times sides, over every region.
+func fencePrices(lines []string) (int, int) {
+	g, area := sparseFromLines(lines)
+	perimeter, sides := 0, 0
+	for _, r := range LabelRegions(g, area, Connect4).Regions {
+		perimeter += r.Area * r.Perimeter
+		sides += r.Area * r.Sides
+	}
+	return perimeter, sides
+}
+
+func TestLabelRegions(t *testing.T) {
+	lines := []string{
+		"AAAA",
+		"BBCD",
+		"BBCC",
+		"EEEC",
+	}
+	g, area := sparseFromLines(lines)
+	regions := LabelRegions(g, area, Connect4)
+	CheckTest(t, "LabelRegions", TTest{Name: "regions", Expect: []Region{
+		{Label: 0, Value: 'A', Start: Point{0, 0}, Area: 4, Perimeter: 10, Sides: 4},
+		{Label: 1, Value: 'B', Start: Point{0, 1}, Area: 4, Perimeter: 8, Sides: 4},
+		{Label: 2, Value: 'C', Start: Point{2, 1}, Area: 4, Perimeter: 10, Sides: 8},
+		{Label: 3, Value: 'D', Start: Point{3, 1}, Area: 1, Perimeter: 4, Sides: 4},
+		{Label: 4, Value: 'E', Start: Point{0, 3}, Area: 3, Perimeter: 8, Sides: 4},
+	}}, regions.Regions)
+	r, _ := regions.RegionAt(Point{3, 3})
+	CheckTest(t, "RegionMap.RegionAt", TTest{Name: "C", Expect: byte('C')}, r.Value)
+	CheckTest(t, "RegionMap.LabelAt", TTest{Name: "outside", Expect: -1}, regions.LabelAt(Point{4, 0}))
+
+	p, s := fencePrices([]string{"OOOOO", "OXOXO", "OOOOO", "OXOXO", "OOOOO"})
+	CheckTest(t, "LabelRegions", TTest{Name: "holes", Expect: []int{772, 436}}, []int{p, s})
+	_, s = fencePrices([]string{"EEEEE", "EXXXX", "EEEEE", "EXXXX", "EEEEE"})
+	CheckTest(t, "LabelRegions", TTest{Name: "E shape", Expect: 236}, s)
+	_, s = fencePrices([]string{"AAAAAA", "AAABBA", "AAABBA", "ABBAAA", "ABBAAA", "AAAAAA"})
+	CheckTest(t, "LabelRegions", TTest{Name: "diagonal touch", Expect: 368}, s)
+	p, s = fencePrices([]string{
+		"RRRRIICCFF",
+		"RRRRIICCCF",
+		"VVRRRCCFFF",
+		"VVRCCCJFFF",
+		"VVVVCJJCFE",
+		"VVIVCCJJEE",
+		"VVIIICJJEE",
+		"MIIIIIJJEE",
+		"MIIISIJEEE",
+		"MMMISSJEEE",
+	})
+	CheckTest(t, "LabelRegions", TTest{Name: "larger", Expect: []int{1930, 1206}}, []int{p, s})
+
+	//8-connectivity joins diagonal neighbours, and a compact grid works too
+	cg := NewCompactGrid(3, 3)
+	cg.SetAt(Point{0, 0}, 1)
+	cg.SetAt(Point{1, 1}, 1)
+	cg.SetAt(Point{2, 2}, 1)
+	CheckTest(t, "LabelRegions", TTest{Name: "4-connected", Expect: 5}, len(LabelRegions(cg, Rect{Max: Point{2, 2}}, Connect4).Regions))
+	diagonal := LabelRegions(cg, Rect{Max: Point{2, 2}}, Connect8)
+	CheckTest(t, "LabelRegions", TTest{Name: "8-connected", Expect: 2}, len(diagonal.Regions))
+	r, _ = diagonal.RegionAt(Point{2, 2})
+	CheckTest(t, "LabelRegions", TTest{Name: "diagonal line", Expect: []int{3, 12, 12}}, []int{r.Area, r.Perimeter, r.Sides})
+}
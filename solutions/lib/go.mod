@@ -3,6 +3,7 @@ module github.com/nfitbh72/aoc2025/lib
 go 1.24.5
 
 require (
+	github.com/google/pprof v0.0.0-20260115054156-294ebfa9ad83
 	golang.org/x/exp v0.0.0-20251125195548-87e1e737ad39
 	gonum.org/v1/gonum v0.16.0
 )
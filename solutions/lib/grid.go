@@ -3,6 +3,8 @@ package eulerlib
 import (
 	"errors"
 	"fmt"
+	"iter"
+	"maps"
 	"strings"
 
 	"golang.org/x/exp/slices"
@@ -95,44 +97,8 @@ func (m *TTable) GetSumOfMaxOfEachRow() (total int) {
 	return total
 }
 
-// TDirection represents a unit step in grid coordinates.
-type TDirection struct {
-	X int
-	Y int
-}
-
-var UpDirection = &TDirection{X: 0, Y: -1}
-var DownDirection = &TDirection{X: 0, Y: 1}
-var LeftDirection = &TDirection{X: -1, Y: 0}
-var RightDirection = &TDirection{X: 1, Y: 0}
-var FailDirection = &TDirection{X: -1, Y: -1}
-
-// DirectionToStr maps the canonical direction pointers to human-readable
-// labels.
-var DirectionToStr = map[*TDirection]string{
-	UpDirection:    "up",
-	DownDirection:  "down",
-	LeftDirection:  "left",
-	RightDirection: "right",
-}
-
-// AllDirections lists the four cardinal directions in clockwise order.
-var AllDirections = []*TDirection{
-	UpDirection,
-	RightDirection,
-	DownDirection,
-	LeftDirection,
-}
-
-// TurnRight provides a right-turn mapping for the four canonical directions.
-var TurnRight = map[*TDirection]*TDirection{
-	UpDirection:    RightDirection,
-	RightDirection: DownDirection,
-	DownDirection:  LeftDirection,
-	LeftDirection:  UpDirection,
-}
-
 // TGrid represents a 2D grid of values, optionally tagged as integer data.
+// Grid is a typed alternative.
 type TGrid struct {
 	Values [][]any
 	IsInt  bool
@@ -258,7 +224,7 @@ func (m *TGrid) CreateSpiralFromCenter(sizeX, sizeY int) {
 		p.Walk()
 
 		//if we turn right is there still a zero?
-		testDirection := TurnRight[p.Direction]
+		testDirection := p.Direction.TurnRight()
 		testY := p.Y + testDirection.Y
 		testX := p.X + testDirection.X
 		if testX < 0 || testY < 0 || testX >= sizeX || testY >= sizeY || m.Values[testY][testX] == 0 {
@@ -368,12 +334,44 @@ func (m *TGrid) WalkFromWithBlocker(gp *TGridPosition, blockingCharacter rune) (
 // returns an error if the new position lies outside the grid bounds.
 func (m *TGrid) WalkFrom(gp *TGridPosition) error {
 	gp.Walk()
-	if gp.X < 0 || gp.Y < 0 || gp.Y >= len(m.Values) || gp.X >= len(m.Values[0]) {
+	if !m.Contains(gp.Point()) {
 		return errors.New("out of bounds of grid")
 	}
 	return nil
 }
 
+// Contains reports whether p lies inside the grid.
+func (m *TGrid) Contains(p Point) bool {
+	return p.Y >= 0 && p.Y < len(m.Values) && p.X >= 0 && p.X < len(m.Values[p.Y])
+}
+
+// GetAt returns the value at p.
+func (m *TGrid) GetAt(p Point) any {
+	return m.Values[p.Y][p.X]
+}
+
+// SetAt assigns a value at p.
+func (m *TGrid) SetAt(p Point, v any) {
+	m.Values[p.Y][p.X] = v
+}
+
+// Find returns the position of the first cell equal to element, or false if
+// there is none. It is FindElement for a Point.
+func (m *TGrid) Find(element any) (Point, bool) {
+	x, y := m.FindElement(element)
+	return Point{x, y}, x >= 0
+}
+
+// Adjacent returns the positions of the (up to) 8 cells around p that
+// contain the specified character. It is GetAdjacentList for a Point.
+func (m *TGrid) Adjacent(p Point, char rune) []Point {
+	var matches []Point
+	for _, xy := range m.GetAdjacentList(p.X, p.Y, char) {
+		matches = append(matches, PointOf(xy))
+	}
+	return matches
+}
+
 // GetValue returns the value at the specified grid position.
 func (m *TGrid) GetValue(gp *TGridPosition) any {
 	return m.Values[gp.Y][gp.X]
@@ -652,13 +650,13 @@ func (m *TGrid) GetAllWords(numChrs int) [][]any {
 type TGridPosition struct {
 	X         int
 	Y         int
-	Direction *TDirection
+	Direction TDirection
 }
 
 // ToString returns a human-readable representation of the grid position and
 // direction.
 func (m *TGridPosition) ToString() string {
-	return fmt.Sprintf("%d, %d: %s", m.X, m.Y, DirectionToStr[m.Direction])
+	return fmt.Sprintf("%d, %d: %s", m.X, m.Y, m.Direction)
 }
 
 // Walk advances the position by one step in its current direction.
@@ -673,11 +671,32 @@ func (m *TGridPosition) NextPos() (int, int) {
 	return m.X + m.Direction.X, m.Y + m.Direction.Y
 }
 
+// Point returns the position's coordinates.
+func (m *TGridPosition) Point() Point {
+	return Point{m.X, m.Y}
+}
+
+// MoveTo moves the position to p, keeping its direction.
+func (m *TGridPosition) MoveTo(p Point) {
+	m.X, m.Y = p.X, p.Y
+}
+
+// NextPoint returns the cell one step ahead in the current direction.
+func (m *TGridPosition) NextPoint() Point {
+	return m.Point().Move(m.Direction)
+}
+
 // ChangeDirection updates the direction of travel for the grid position.
-func (m *TGridPosition) ChangeDirection(direction *TDirection) {
+func (m *TGridPosition) ChangeDirection(direction TDirection) {
 	m.Direction = direction
 }
 
+// Turn turns the direction of travel clockwise by n steps of 45 degrees, or
+// anticlockwise if n is negative.
+func (m *TGridPosition) Turn(n int) {
+	m.Direction = m.Direction.Turn(n)
+}
+
 type CompactGrid struct {
 	width  int
 	height int
@@ -707,6 +726,26 @@ func (g *CompactGrid) Get(x, y int) byte {
 	return (g.data[byteIdx] >> bitOffset) & 0x03
 }
 
+// Contains reports whether p lies inside the grid.
+func (g *CompactGrid) Contains(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.width && p.Y < g.height
+}
+
+// Size returns the width and height of the grid.
+func (g *CompactGrid) Size() GridSize {
+	return GridSize{Width: g.width, Height: g.height}
+}
+
+// GetAt is Get for a Point.
+func (g *CompactGrid) GetAt(p Point) byte {
+	return g.Get(p.X, p.Y)
+}
+
+// SetAt is Set for a Point.
+func (g *CompactGrid) SetAt(p Point, value byte) {
+	g.Set(p.X, p.Y, value)
+}
+
 func (g *CompactGrid) ToString(minX, maxX, minY, maxY int) string {
 	var sb strings.Builder
 	for y := minY; y <= maxY; y++ {
@@ -727,6 +766,10 @@ func (g *CompactGrid) ToString(minX, maxX, minY, maxY int) string {
 	return sb.String()
 }
 
+// FillEnclosedArea sets the empty cells between boundary cells on each row
+// to fillValue, toggling inside and outside at each run of boundary cells.
+// A boundary running along a row throws the toggle out; FillEnclosed flood
+// fills from the edge instead.
 func (g *CompactGrid) FillEnclosedArea(minX, maxX, minY, maxY int, fillValue byte, boundaryMap map[byte]bool) int {
 	count := 0
 	for y := minY; y <= maxY; y++ {
@@ -754,23 +797,55 @@ func (g *CompactGrid) FillEnclosedArea(minX, maxX, minY, maxY int, fillValue byt
 }
 
 type SparseGrid struct {
-	data map[[2]int]byte // key: [x,y], value: 'R' or 'G'
+	data map[Point]byte // value: 'R' or 'G'
 }
 
 func NewSparseGrid() *SparseGrid {
-	return &SparseGrid{data: make(map[[2]int]byte)}
+	return &SparseGrid{data: make(map[Point]byte)}
 }
 
 func (g *SparseGrid) Set(x, y int, value byte) {
+	g.SetAt(Point{x, y}, value)
+}
+
+func (g *SparseGrid) Get(x, y int) byte {
+	return g.data[Point{x, y}] // returns 0 if not found
+}
+
+// SetAt is Set for a Point.
+func (g *SparseGrid) SetAt(p Point, value byte) {
 	if value == 0 {
-		delete(g.data, [2]int{x, y})
+		delete(g.data, p)
 	} else {
-		g.data[[2]int{x, y}] = value
+		g.data[p] = value
 	}
 }
 
-func (g *SparseGrid) Get(x, y int) byte {
-	return g.data[[2]int{x, y}] // returns 0 if not found
+// GetAt is Get for a Point.
+func (g *SparseGrid) GetAt(p Point) byte {
+	return g.data[p]
+}
+
+// Points iterates over the cells that are set, in no particular order.
+func (g *SparseGrid) Points() iter.Seq2[Point, byte] {
+	return maps.All(g.data)
+}
+
+// Extent returns the smallest rectangle holding every cell that is set, or
+// false if none are.
+func (g *SparseGrid) Extent() (Rect, bool) {
+	var r Rect
+	first := true
+	for p := range g.data {
+		if first {
+			r = Rect{Min: p, Max: p}
+			first = false
+			continue
+		}
+		r.Min = Point{min(r.Min.X, p.X), min(r.Min.Y, p.Y)}
+		r.Max = Point{max(r.Max.X, p.X), max(r.Max.Y, p.Y)}
+	}
+	return r, !first
 }
 
 func (g *SparseGrid) ToString(minX, maxX, minY, maxY int) string {
@@ -789,6 +864,10 @@ func (g *SparseGrid) ToString(minX, maxX, minY, maxY int) string {
 	return sb.String()
 }
 
+// FillEnclosedArea sets the empty cells between boundary cells on each row
+// to fillValue, toggling inside and outside at each run of boundary cells.
+// A boundary running along a row throws the toggle out; FillEnclosed flood
+// fills from the edge instead.
 func (g *SparseGrid) FillEnclosedArea(minX, maxX, minY, maxY int, fillValue byte, boundaryMap map[byte]bool) int {
 	count := 0
 	for y := minY; y <= maxY; y++ {
@@ -1438,3 +1438,48 @@ func TestSparseGridFillEnclosedAreaNoFill(t *testing.T) {
 		t.Errorf("FillEnclosedArea() with no boundaries filled %d cells, want 0", count)
 	}
 }
+
+func TestGridPoints(t *testing.T) {
+	CheckTest(t, "TDirection.Point", TTest{Name: "down", Expect: Point{0, 1}}, DownDirection.Point())
+	d, ok := DirectionOf(Point{-1, 0})
+	CheckTest(t, "DirectionOf", TTest{Name: "left", Expect: []any{LeftDirection, true}}, []any{d, ok})
+	_, ok = DirectionOf(Point{2, 0})
+	CheckTest(t, "DirectionOf", TTest{Name: "not a unit step", Expect: false}, ok)
+
+	gp := &TGridPosition{X: 1, Y: 2, Direction: UpDirection}
+	CheckTest(t, "TGridPosition.NextPoint", TTest{Name: "up", Expect: Point{1, 1}}, gp.NextPoint())
+	gp.MoveTo(Point{4, 4})
+	CheckTest(t, "TGridPosition.Point", TTest{Name: "moved", Expect: Point{4, 4}}, gp.Point())
+
+	g := &TGrid{}
+	g.Init()
+	g.ParseTable([]string{"#.S", "##."}, false)
+	p, ok := g.Find('S')
+	CheckTest(t, "TGrid.Find", TTest{Name: "found", Expect: []any{Point{2, 0}, true}}, []any{p, ok})
+	_, ok = g.Find('x')
+	CheckTest(t, "TGrid.Find", TTest{Name: "missing", Expect: false}, ok)
+	CheckTest(t, "TGrid.Contains", TTest{Name: "outside", Expect: false}, g.Contains(Point{3, 0}))
+	g.SetAt(Point{1, 0}, '#')
+	CheckTest(t, "TGrid.GetAt", TTest{Name: "set", Expect: '#'}, g.GetAt(Point{1, 0}))
+	CheckTest(t, "TGrid.Adjacent", TTest{Name: "adjacent", Expect: []Point{{0, 0}, {1, 0}, {1, 1}}}, g.Adjacent(Point{0, 1}, '#'))
+
+	cg := NewCompactGrid(3, 2)
+	cg.SetAt(Point{2, 1}, 2)
+	CheckTest(t, "CompactGrid.GetAt", TTest{Name: "set", Expect: byte(2)}, cg.GetAt(Point{2, 1}))
+	CheckTest(t, "CompactGrid.Contains", TTest{Name: "outside", Expect: false}, cg.Contains(Point{3, 1}))
+	CheckTest(t, "CompactGrid.Size", TTest{Name: "size", Expect: GridSize{Width: 3, Height: 2}}, cg.Size())
+
+	sg := NewSparseGrid()
+	_, ok = sg.Extent()
+	CheckTest(t, "SparseGrid.Extent", TTest{Name: "empty", Expect: false}, ok)
+	sg.SetAt(Point{-2, 5}, 'R')
+	sg.Set(3, 1, 'G')
+	CheckTest(t, "SparseGrid.GetAt", TTest{Name: "set", Expect: byte('G')}, sg.GetAt(Point{3, 1}))
+	r, _ := sg.Extent()
+	CheckTest(t, "SparseGrid.Extent", TTest{Name: "extent", Expect: Rect{Min: Point{-2, 1}, Max: Point{3, 5}}}, r)
+	count := 0
+	for range sg.Points() {
+		count++
+	}
+	CheckTest(t, "SparseGrid.Points", TTest{Name: "count", Expect: 2}, count)
+}
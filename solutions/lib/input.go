@@ -0,0 +1,229 @@
+package eulerlib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// InputDirEnv names the environment variable holding a directory of
+// dayX-Y/input files that takes precedence over each package's own inputs.
+const InputDirEnv = "AOC_INPUT_DIR"
+
+// InputCacheEnv names the environment variable that overrides the location
+// of the shared inputs cache.
+const InputCacheEnv = "AOC_CACHE_DIR"
+
+// inputDirOverride is set from a command line flag through SetInputDir and
+// is searched before every other location.
+var inputDirOverride string
+
+// SetInputDir sets a directory of dayX-Y/input files to search before the
+// AOC_INPUT_DIR environment variable, package directories and the cache. It
+// is intended to be called once from a command line flag; an empty dir
+// removes the override.
+func SetInputDir(dir string) {
+	inputDirOverride = dir
+}
+
+// GetInputDir returns the directory set by SetInputDir.
+func GetInputDir() string {
+	return inputDirOverride
+}
+
+// InputCacheDir returns the root of the shared inputs cache, which holds
+// files as <root>/<year>/day<day>/<filename>. It is AOC_CACHE_DIR when set
+// and otherwise an "aoc" directory in the user's cache directory.
+func InputCacheDir() string {
+	if dir := os.Getenv(InputCacheEnv); dir != "" {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aoc")
+}
+
+// SplitLines splits text into lines, treating "\r\n" as a line ending and
+// dropping the empty line that strings.Split produces after a trailing
+// newline.
+func SplitLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.TrimSuffix(s, "\n")
+	return strings.Split(s, "\n")
+}
+
+// ReadLines reads the named file and returns its lines as split by
+// SplitLines.
+func ReadLines(filename string) ([]string, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return SplitLines(string(b)), nil
+}
+
+// InputNotFoundError reports an input file that was not found in any of the
+// searched locations.
+type InputNotFoundError struct {
+	Filename string
+	Searched []string
+}
+
+// Error lists every path that was tried.
+func (e *InputNotFoundError) Error() string {
+	return fmt.Sprintf("input %s not found; searched %s", e.Filename, strings.Join(e.Searched, ", "))
+}
+
+// Unwrap lets errors.Is(err, fs.ErrNotExist) recognise a missing input.
+func (e *InputNotFoundError) Unwrap() error {
+	return fs.ErrNotExist
+}
+
+// InputSearch describes where a solution's input files may be found.
+type InputSearch struct {
+	Year int
+	Day  int
+	// Folder is the "dayX-Y" folder name used beneath the flag and
+	// AOC_INPUT_DIR directories.
+	Folder string
+	// Dir is the solution's package directory. It is empty when that is not
+	// known, as in a binary built with -trimpath, and the working directory
+	// stands in for it.
+	Dir string
+}
+
+// inputSearchForDir builds an InputSearch for a package directory, taking the
+// day from a "dayX-Y" directory name when there is one.
+func inputSearchForDir(dir string) InputSearch {
+	search := InputSearch{Year: DefaultYear, Dir: dir}
+	if match := folderNameRe.FindStringSubmatch(filepath.Base(dir)); match != nil {
+		search.Folder = match[0]
+		search.Day, _ = strconv.Atoi(match[1])
+	}
+	return search
+}
+
+// Candidates returns the paths tried for filename, in order: the SetInputDir
+// directory, the AOC_INPUT_DIR directory, the package directory and then the
+// shared cache. Without a package directory the Folder beneath the working
+// directory and the working directory itself are tried in its place, which
+// suit running from the solutions root and from the solution's own folder.
+// Absolute filenames are returned unchanged.
+func (m InputSearch) Candidates(filename string) []string {
+	if filepath.IsAbs(filename) {
+		return []string{filename}
+	}
+	candidates := []string{}
+	if m.Folder != "" {
+		for _, root := range []string{inputDirOverride, os.Getenv(InputDirEnv)} {
+			if root != "" {
+				candidates = append(candidates, filepath.Join(root, m.Folder, filename))
+			}
+		}
+	}
+	switch {
+	case m.Dir != "":
+		candidates = append(candidates, filepath.Join(m.Dir, filename))
+	case m.Folder != "":
+		candidates = append(candidates, filepath.Join(m.Folder, filename), filename)
+	default:
+		candidates = append(candidates, filename)
+	}
+	if cache := InputCacheDir(); cache != "" && m.Day > 0 {
+		candidates = append(candidates, inputCachePath(cache, m.Year, m.Day, filename))
+	}
+	return candidates
+}
+
+// inputCachePath returns the path of filename for a year and day within the
+// cache rooted at cache.
+func inputCachePath(cache string, year, day int, filename string) string {
+	return filepath.Join(cache, IntToStr(year), fmt.Sprintf("day%d", day), filename)
+}
+
+// Resolve returns the first candidate path for filename that exists. If none
+// does and a Fetcher has been set with SetInputFetcher, an input or example
+// is downloaded into the cache instead.
+func (m InputSearch) Resolve(filename string) (string, error) {
+	candidates := m.Candidates(filename)
+	for _, path := range candidates {
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	if inputFetcher != nil && m.Day > 0 && (filename == DefaultInput || filename == DefaultShortInput) {
+		path, err := inputFetcher.Fetch(context.Background(), m.Year, m.Day, filename)
+		if err != nil {
+			return "", fmt.Errorf("%w; fetching: %w", &InputNotFoundError{Filename: filename, Searched: candidates}, err)
+		}
+		return path, nil
+	}
+	return "", &InputNotFoundError{Filename: filename, Searched: candidates}
+}
+
+// Load resolves filename and reads its lines.
+func (m InputSearch) Load(filename string) ([]string, error) {
+	path, err := m.Resolve(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ReadLines(path)
+}
+
+// callerInputSearch returns the InputSearch for the package of the function
+// skip frames above its caller. The day comes from the "dayX-Y" folder of the
+// caller's source file, and its directory is used only if sourceDir can find
+// it on this machine.
+func callerInputSearch(skip int) InputSearch {
+	_, file, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return InputSearch{Year: DefaultYear}
+	}
+	search := inputSearchForDir(filepath.Dir(file))
+	search.Dir = sourceDir(file)
+	return search
+}
+
+// sourceDir returns the directory of a source file path compiled into the
+// binary, or "" if it is not there to use: a binary built with -trimpath
+// records module-relative paths, and one moved to another machine records
+// directories that do not exist on it.
+func sourceDir(file string) string {
+	dir := filepath.Dir(file)
+	if !filepath.IsAbs(dir) {
+		return ""
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+// LoadInput finds filename for the calling solution package and returns its
+// lines. The error names the file and every location that was searched.
+func LoadInput(filename string) ([]string, error) {
+	return callerInputSearch(1).Load(filename)
+}
+
+// MustLoadInput is like LoadInput but panics if the input cannot be loaded.
+// It suits the string based Problem methods, where TestProblem and the runner
+// report the panic message.
+func MustLoadInput(filename string) []string {
+	lines, err := callerInputSearch(1).Load(filename)
+	if err != nil {
+		panic(err)
+	}
+	return lines
+}
@@ -0,0 +1,200 @@
+package eulerlib
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSplitLines(t *testing.T) {
+	tests := []TTest{
+		{Name: "no trailing newline", Input: "a\nb", Expect: []string{"a", "b"}},
+		{Name: "trailing newline", Input: "a\nb\n", Expect: []string{"a", "b"}},
+		{Name: "crlf", Input: "a\r\nb\r\n", Expect: []string{"a", "b"}},
+		{Name: "blank separator kept", Input: "a\n\nb\n", Expect: []string{"a", "", "b"}},
+		{Name: "only last blank dropped", Input: "a\n\n", Expect: []string{"a", ""}},
+		{Name: "trailing spaces kept", Input: "a \n", Expect: []string{"a "}},
+	}
+	for _, test := range tests {
+		CheckTest(t, "input.SplitLines", test, SplitLines(test.Input.(string)))
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("L68\r\nR48\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := ReadLines(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "input.ReadLines", TTest{Name: "crlf file", Expect: []string{"L68", "R48"}}, lines)
+
+	_, err = ReadLines(filepath.Join(t.TempDir(), "missing.txt"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a not-exist error, got %v", err)
+	}
+}
+
+func TestInputSearchCandidates(t *testing.T) {
+	t.Setenv(InputDirEnv, "/env")
+	t.Setenv(InputCacheEnv, "/cache")
+	SetInputDir("/flag")
+	t.Cleanup(func() { SetInputDir("") })
+
+	search := inputSearchForDir("/src/day7-2")
+	expect := []string{
+		filepath.Join("/flag", "day7-2", "input.txt"),
+		filepath.Join("/env", "day7-2", "input.txt"),
+		filepath.Join("/src/day7-2", "input.txt"),
+		filepath.Join("/cache", "2025", "day7", "input.txt"),
+	}
+	CheckTest(t, "input.Candidates", TTest{Name: "search order", Expect: expect}, search.Candidates("input.txt"))
+	CheckTest(t, "input.Candidates", TTest{Name: "absolute", Expect: []string{"/abs/in.txt"}}, search.Candidates("/abs/in.txt"))
+
+	plain := inputSearchForDir("/src/lib")
+	CheckTest(t, "input.Candidates", TTest{Name: "not a day folder", Expect: []string{filepath.Join("/src/lib", "input.txt")}}, plain.Candidates("input.txt"))
+
+	//a -trimpath build knows the day but not where its sources are
+	SetInputDir("")
+	trimmed := InputSearch{Year: DefaultYear, Day: 7, Folder: "day7-2"}
+	expect = []string{
+		filepath.Join("/env", "day7-2", "input.txt"),
+		filepath.Join("day7-2", "input.txt"),
+		"input.txt",
+		filepath.Join("/cache", "2025", "day7", "input.txt"),
+	}
+	CheckTest(t, "input.Candidates", TTest{Name: "no package directory", Expect: expect}, trimmed.Candidates("input.txt"))
+}
+
+func TestSourceDir(t *testing.T) {
+	dir := t.TempDir()
+	tests := []TTest{
+		{Name: "built here", Input: filepath.Join(dir, "main.go"), Expect: dir},
+		{Name: "trimpath", Input: "github.com/nfitbh72/aoc2025/solutions/day1-1/main.go", Expect: ""},
+		{Name: "moved binary", Input: filepath.Join(dir, "gone", "main.go"), Expect: ""},
+	}
+	for _, test := range tests {
+		CheckTest(t, "input.sourceDir", test, sourceDir(test.Input.(string)))
+	}
+	search := callerInputSearch(0)
+	CheckTest(t, "input.callerInputSearch", TTest{Name: "this package", Expect: true}, filepath.IsAbs(search.Dir))
+}
+
+func TestInputSearchResolveOrder(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pkg := filepath.Join(root, "src", "day3-1")
+	env := filepath.Join(root, "env")
+	cache := filepath.Join(root, "cache")
+	t.Setenv(InputDirEnv, env)
+	t.Setenv(InputCacheEnv, cache)
+	search := inputSearchForDir(pkg)
+
+	write(filepath.Join(cache, "2025", "day3", "input.txt"), "cache")
+	lines, err := search.Load("input.txt")
+	CheckTest(t, "input.Load", TTest{Name: "cache", Expect: []string{"cache"}}, lines)
+
+	write(filepath.Join(pkg, "input.txt"), "package")
+	lines, _ = search.Load("input.txt")
+	CheckTest(t, "input.Load", TTest{Name: "package before cache", Expect: []string{"package"}}, lines)
+
+	write(filepath.Join(env, "day3-1", "input.txt"), "env")
+	lines, _ = search.Load("input.txt")
+	CheckTest(t, "input.Load", TTest{Name: "env before package", Expect: []string{"env"}}, lines)
+
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestInputSearchNotFound(t *testing.T) {
+	t.Setenv(InputCacheEnv, t.TempDir())
+	search := inputSearchForDir(filepath.Join(t.TempDir(), "day4-2"))
+	_, err := search.Load("input.txt")
+	var notFound *InputNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected InputNotFoundError, got %v", err)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("expected InputNotFoundError to match fs.ErrNotExist")
+	}
+	CheckTest(t, "input.Load", TTest{Name: "searched", Expect: 2}, len(notFound.Searched))
+	if !strings.Contains(err.Error(), filepath.Join("day4-2", "input.txt")) {
+		t.Errorf("expected error to name the package input, got %v", err)
+	}
+}
+
+func TestLoadInputFromCallerPackage(t *testing.T) {
+	lines, err := LoadInput("input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "input.LoadInput", TTest{Name: "lib input", Expect: []string{"this is a test", "this is the same test"}}, lines)
+
+	_, err = LoadInput("definitely-does-not-exist-123456.txt")
+	if err == nil || !strings.Contains(err.Error(), "definitely-does-not-exist-123456.txt not found") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestMustLoadInputPanics(t *testing.T) {
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); !ok || !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected MustLoadInput to panic with a not found error, got %v", r)
+		}
+	}()
+	MustLoadInput("definitely-does-not-exist-123456.txt")
+}
+
+func TestProblemInfoLoadInput(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "day2-1")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "input-test.txt"), []byte("11-22\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	info := ProblemInfo{Year: 2025, Day: 2, Part: 1, Dir: dir, Input: "input.txt", ShortInput: "input-test.txt"}
+	lines, err := info.LoadInput(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "registry.LoadInput", TTest{Name: "short", Expect: []string{"11-22"}}, lines)
+	if _, err := info.LoadInput(false); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a not found error for the full input, got %v", err)
+	}
+}
+
+// TMissingInputProblem reads an input file that does not exist.
+type TMissingInputProblem struct {
+	Problem
+}
+
+func (m *TMissingInputProblem) GetProblemName() string { return "Missing Input Problem" }
+
+func (m *TMissingInputProblem) GetAnswer() string { return "1" }
+
+func (m *TMissingInputProblem) GenerateAnswer() string {
+	return IntToStr(len(MustLoadInput("definitely-does-not-exist-123456.txt")))
+}
+
+func TestTestProblemReportsMissingInput(t *testing.T) {
+	var fake testing.T
+	TestProblem(&TMissingInputProblem{}, &fake)
+	if !fake.Failed() {
+		t.Error("expected TestProblem to fail when the input file is missing")
+	}
+}
@@ -0,0 +1,289 @@
+package eulerlib
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// LargeGridCells is the number of cells above which Inspect warns that a
+// dense grid over the input's coordinates would be too big to allocate.
+const LargeGridCells = 100_000_000
+
+var (
+	shapeDigitsRe = regexp.MustCompile(`\d+`)
+	shapeWordRe   = regexp.MustCompile(`\pL{2,}`)
+	// shapeIntsRe and shapeWordsRe match lists of three or more integers or
+	// words in a shape.
+	shapeIntsRe  = regexp.MustCompile(`N(?:(, ?| )N){2,}`)
+	shapeWordsRe = regexp.MustCompile(`w(?:(, ?| )w){2,}`)
+	shapeSpaceRe = regexp.MustCompile(`[ \t]+`)
+	// gridRowRe matches a line of symbols, with no letters, digits or
+	// spaces, such as a grid row.
+	gridRowRe = regexp.MustCompile(`^[^\pL\pN\s]+$`)
+)
+
+// InputStats summarises the shape of a puzzle input, for a first look before
+// writing a solution.
+type InputStats struct {
+	Lines      int
+	BlankLines int
+	// Width is the length of the longest line, in characters.
+	Width int
+	// Shapes are the distinct line shapes, most common first.
+	Shapes []LineShape
+	// Chars counts each character, most common first.
+	Chars []CharCount
+	// Grid is the size of the input when it is a single rectangular block of
+	// two or more lines, and zero otherwise.
+	Grid GridSize
+	// Ints is the range of every integer in the input.
+	Ints IntRange
+	// Fields is the range of each integer in the lines of the most common
+	// shape, when it is the shape of most lines and has a fixed number of
+	// integers.
+	Fields   []IntRange
+	Sections []SectionStats
+	Warnings []string
+}
+
+// LineShape is a kind of line. A line's shape replaces each word of two or
+// more letters with w and each integer with N, squeezes runs of spaces and
+// shortens lists of three or more integers or words. So "Button A: X+94,
+// Y+34" has the shape "w A: X+N, Y+N" and "move 3: 1,2,3" has "w N: N,...".
+// A line of symbols such as a grid row has the shape of its characters and
+// length, "[#.]x12".
+type LineShape struct {
+	Shape string
+	Count int
+	// Line is the first line with the shape, numbered from 1.
+	Line int
+}
+
+// CharCount is the number of times a character appears.
+type CharCount struct {
+	Char  rune
+	Count int
+}
+
+// GridSize is the width and height of a rectangular block of lines.
+type GridSize struct {
+	Width, Height int
+}
+
+// IsZero reports whether the size is unset, as for an input that is not a
+// grid.
+func (m GridSize) IsZero() bool {
+	return m.Width == 0 && m.Height == 0
+}
+
+// String formats the size as "WxH".
+func (m GridSize) String() string {
+	return fmt.Sprintf("%dx%d", m.Width, m.Height)
+}
+
+// IntRange is the number of integers seen and their least and greatest
+// values.
+type IntRange struct {
+	Count    int
+	Min, Max int
+}
+
+// add widens the range to include v.
+func (m *IntRange) add(v int) {
+	if m.Count == 0 || v < m.Min {
+		m.Min = v
+	}
+	if m.Count == 0 || v > m.Max {
+		m.Max = v
+	}
+	m.Count++
+}
+
+// Span is the number of values from Min to Max inclusive.
+func (m IntRange) Span() int {
+	if m.Count == 0 {
+		return 0
+	}
+	return m.Max - m.Min + 1
+}
+
+// SectionStats describes one of the blank-line separated sections of an
+// input.
+type SectionStats struct {
+	// Line is the line number of the section's first line.
+	Line  int
+	Lines int
+	// Shapes is the number of distinct line shapes in the section.
+	Shapes int
+	// Grid is the size of the section when its lines are all the same
+	// length, and zero otherwise.
+	Grid GridSize
+}
+
+// ShapeOf returns the shape of a line, as described for LineShape.
+func ShapeOf(line string) string {
+	if gridRowRe.MatchString(line) {
+		chars := []rune(line)
+		width := len(chars)
+		slices.Sort(chars)
+		return fmt.Sprintf("[%s]x%d", string(slices.Compact(chars)), width)
+	}
+	shape := shapeSpaceRe.ReplaceAllString(strings.TrimSpace(line), " ")
+	shape = shapeDigitsRe.ReplaceAllString(shapeWordRe.ReplaceAllString(shape, "w"), "N")
+	shape = shapeIntsRe.ReplaceAllString(shape, "N$1...")
+	return shapeWordsRe.ReplaceAllString(shape, "w$1...")
+}
+
+// Inspect gathers the statistics "aoc inspect" reports for an input.
+func Inspect(lines []string) InputStats {
+	stats := InputStats{Lines: len(lines)}
+	shapes := map[string]*LineShape{}
+	chars := map[rune]int{}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			stats.BlankLines++
+			continue
+		}
+		stats.Width = max(stats.Width, utf8.RuneCountInString(line))
+		for _, c := range line {
+			chars[c]++
+		}
+		for _, v := range lineInts(line) {
+			stats.Ints.add(v)
+		}
+		shape := ShapeOf(line)
+		if shapes[shape] == nil {
+			shapes[shape] = &LineShape{Shape: shape, Line: i + 1}
+		}
+		shapes[shape].Count++
+	}
+
+	for _, shape := range shapes {
+		stats.Shapes = append(stats.Shapes, *shape)
+	}
+	slices.SortFunc(stats.Shapes, func(a, b LineShape) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Line, b.Line))
+	})
+	for c, n := range chars {
+		stats.Chars = append(stats.Chars, CharCount{Char: c, Count: n})
+	}
+	slices.SortFunc(stats.Chars, func(a, b CharCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Char, b.Char))
+	})
+
+	for _, section := range SplitSections(lines) {
+		sectionShapes := map[string]bool{}
+		for _, line := range section.Lines {
+			sectionShapes[ShapeOf(line)] = true
+		}
+		stats.Sections = append(stats.Sections, SectionStats{
+			Line:   section.Line,
+			Lines:  len(section.Lines),
+			Shapes: len(sectionShapes),
+			Grid:   gridSize(section.Lines),
+		})
+	}
+	if len(stats.Sections) == 1 && stats.Sections[0].Lines > 1 {
+		stats.Grid = stats.Sections[0].Grid
+	}
+
+	if len(stats.Shapes) > 0 && stats.Shapes[0].Count*2 > stats.Lines-stats.BlankLines && !strings.Contains(stats.Shapes[0].Shape, "...") {
+		stats.Fields = shapeFields(lines, stats.Shapes[0].Shape)
+	}
+	stats.Warnings = inputWarnings(stats)
+	return stats
+}
+
+// gridSize returns the size of lines as a grid, or zero if they are not all
+// the same length.
+func gridSize(lines []string) GridSize {
+	if len(lines) == 0 {
+		return GridSize{}
+	}
+	width := utf8.RuneCountInString(lines[0])
+	for _, line := range lines[1:] {
+		if utf8.RuneCountInString(line) != width {
+			return GridSize{}
+		}
+	}
+	return GridSize{Width: width, Height: len(lines)}
+}
+
+// shapeFields returns the range of each integer in the lines with the given
+// shape, or nil if the lines do not all have the same number of integers.
+func shapeFields(lines []string, shape string) []IntRange {
+	var fields []IntRange
+	for _, line := range lines {
+		if ShapeOf(line) != shape {
+			continue
+		}
+		ints := lineInts(line)
+		if fields == nil {
+			fields = make([]IntRange, len(ints))
+		}
+		if len(ints) != len(fields) {
+			return nil
+		}
+		for i, v := range ints {
+			fields[i].add(v)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// lineInts returns the integers in line, one for each run of digits. Unlike
+// FindInts it only takes a '-' as a sign when it does not follow a digit or
+// letter, so the range "3-5" is 3 and 5 rather than 3 and -5.
+func lineInts(line string) []int {
+	var ints []int
+	for _, loc := range shapeDigitsRe.FindAllStringIndex(line, -1) {
+		start := loc[0]
+		if start > 0 && line[start-1] == '-' {
+			if start == 1 || !isWordByte(line[start-2]) {
+				start--
+			}
+		}
+		v, err := ParseInt(line[start:loc[1]])
+		if err != nil {
+			//too big for an int; keep the count of fields right
+			v = 0
+		}
+		ints = append(ints, v)
+	}
+	return ints
+}
+
+// isWordByte reports whether b is an ASCII letter or digit.
+func isWordByte(b byte) bool {
+	return b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// inputWarnings points out inputs that are likely to trip up a solution,
+// such as coordinates spanning a grid too large to allocate.
+func inputWarnings(stats InputStats) []string {
+	var warnings []string
+	if len(stats.Fields) == 2 {
+		x, y := stats.Fields[0], stats.Fields[1]
+		if x.Span() > LargeGridCells/max(y.Span(), 1) {
+			warnings = append(warnings, fmt.Sprintf(
+				"as x,y points the input spans %dx%d = %.3g cells; use a sparse grid or compress the coordinates",
+				x.Span(), y.Span(), float64(x.Span())*float64(y.Span())))
+		}
+	}
+	if stats.Grid.IsZero() && len(stats.Sections) == 1 && len(stats.Shapes) > 1 {
+		for _, shape := range stats.Shapes {
+			if !strings.HasPrefix(shape.Shape, "[") || !strings.Contains(shape.Shape, "]x") {
+				return warnings
+			}
+		}
+		warnings = append(warnings, "the lines look like grid rows but are not all the same length")
+	}
+	return warnings
+}
@@ -0,0 +1,53 @@
+package eulerlib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShapeOf(t *testing.T) {
+	tests := map[string]string{
+		"Button A: X+94, Y+34":       "w A: X+N, Y+N",
+		"12x5: 1 0 1 0 2 2":          "NxN: N ...",
+		"97918,50201":                "N,N",
+		"move 3: 1,2,3":              "w N: N,...",
+		"aaa: you hhh ccc":           "w: w ...",
+		"  12    7  ":                "N N",
+		"..#.#":                      "[#.]x5",
+		"[.##.] (3) (1,3) {3,5,4,7}": "[.##.] (N) (N,N) {N,...}",
+		"3-5":                        "N-N",
+	}
+	for line, expect := range tests {
+		CheckTest(t, "inspect.ShapeOf", TTest{Name: line, Expect: expect}, ShapeOf(line))
+	}
+}
+
+func TestInspect(t *testing.T) {
+	stats := Inspect([]string{"#.#", "...", "#.."})
+	CheckTest(t, "inspect.Inspect", TTest{Name: "grid", Expect: GridSize{3, 3}}, stats.Grid)
+	CheckTest(t, "inspect.Inspect", TTest{Name: "chars", Expect: []CharCount{{'.', 6}, {'#', 3}}}, stats.Chars)
+	CheckTest(t, "inspect.Inspect", TTest{Name: "shapes", Expect: []LineShape{{"[#.]x3", 2, 1}, {"[.]x3", 1, 2}}}, stats.Shapes)
+	CheckTest(t, "inspect.Inspect", TTest{Name: "no ints", Expect: IntRange{}}, stats.Ints)
+
+	stats = Inspect([]string{"3-5", "10-14", "", "-1", "17"})
+	CheckTest(t, "inspect.Inspect", TTest{Name: "blank", Expect: 1}, stats.BlankLines)
+	CheckTest(t, "inspect.Inspect", TTest{Name: "ranges are not negative", Expect: IntRange{Count: 6, Min: -1, Max: 17}}, stats.Ints)
+	CheckTest(t, "inspect.Inspect", TTest{Name: "not a grid", Expect: true}, stats.Grid.IsZero())
+	CheckTest(t, "inspect.Inspect", TTest{Name: "sections", Expect: []SectionStats{
+		{Line: 1, Lines: 2, Shapes: 1},
+		{Line: 4, Lines: 2, Shapes: 2, Grid: GridSize{2, 2}},
+	}}, stats.Sections)
+	CheckTest(t, "inspect.Inspect", TTest{Name: "no majority shape", Expect: 0}, len(stats.Fields))
+}
+
+func TestInspectWarnings(t *testing.T) {
+	stats := Inspect([]string{"7,1", "98000,5", "11,99999"})
+	CheckTest(t, "inspect.Inspect", TTest{Name: "fields", Expect: []IntRange{{3, 7, 98000}, {3, 1, 99999}}}, stats.Fields)
+	if len(stats.Warnings) != 1 || !strings.Contains(stats.Warnings[0], "spans 97994x99999") {
+		t.Errorf("expected a warning about the grid size, got %q", stats.Warnings)
+	}
+	CheckTest(t, "inspect.Inspect", TTest{Name: "small points", Expect: 0}, len(Inspect([]string{"1,2", "30,40"}).Warnings))
+
+	stats = Inspect([]string{"#..", "#.", "..#"})
+	CheckTest(t, "inspect.Inspect", TTest{Name: "ragged grid", Expect: []string{"the lines look like grid rows but are not all the same length"}}, stats.Warnings)
+}
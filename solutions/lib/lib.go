@@ -8,7 +8,6 @@ import (
 	"math/rand/v2"
 	"os"
 	"reflect"
-	"strconv"
 	"strings"
 
 	"golang.org/x/exp/slices"
@@ -21,16 +20,16 @@ func GetFirstArgAsInt() int {
 	return StrToInt(os.Args[1])
 }
 
-// GetFileInputTxt reads the entire contents of the named file and returns a
-// slice of lines split on '\n'. On error it logs and returns nil.
+// GetFileInputTxt finds the named file for the calling package, searching
+// the same locations as LoadInput, and returns its lines. On error it logs and
+// returns nil; prefer LoadInput or MustLoadInput, which report the error.
 func GetFileInputTxt(filename string) []string {
-	b, err := os.ReadFile(filename)
+	lines, err := callerInputSearch(1).Load(filename)
 	if err != nil {
 		log.Println(err)
 		return nil
 	}
-	s := string(b)
-	return strings.Split(s, "\n")
+	return lines
 }
 
 // IntAbs returns the absolute value of x.
@@ -50,8 +49,16 @@ func Int64Abs(x int64) int64 {
 }
 
 // StrToInt converts a decimal string to an int, returning 0 on parse error.
+// ParseInt returns the error instead, and Conv.StrToInt panics with it in
+// strict mode.
 func StrToInt(v string) int {
-	i, _ := strconv.Atoi(v)
+	return Conv{}.StrToInt(v)
+}
+
+// StrToInt is StrToInt reporting a parse error as c does.
+func (c Conv) StrToInt(v string) int {
+	i, err := ParseInt(v)
+	c.check(err)
 	return i
 }
 
@@ -70,9 +77,15 @@ func BigIntToStr(i *big.Int) string {
 	return fmt.Sprintf("%d", i)
 }
 
-// AnyToInt asserts that a holds an int and returns it.
+// AnyToInt asserts that a holds an int and returns it, panicking with an
+// error saying what a holds if it does not. ToInt converts other integer
+// types and returns the error.
 func AnyToInt(a any) int {
-	return a.(int)
+	i, ok := a.(int)
+	if !ok {
+		panic(fmt.Errorf("cannot convert %T %v to an int", a, a))
+	}
+	return i
 }
 
 // RuneToInt converts a numeric rune ('0'..'9') to its integer value.
@@ -200,9 +213,14 @@ func CollatzSequence(n int) (count int) {
 
 // GetStrArrAsIntArr converts a slice of decimal strings to a slice of ints.
 func GetStrArrAsIntArr(strs []string) []int {
+	return Conv{}.GetStrArrAsIntArr(strs)
+}
+
+// GetStrArrAsIntArr is GetStrArrAsIntArr reporting parse errors as c does.
+func (c Conv) GetStrArrAsIntArr(strs []string) []int {
 	i := make([]int, 0)
 	for _, s := range strs {
-		i = append(i, StrToInt(s))
+		i = append(i, c.StrToInt(s))
 	}
 	return i
 }
@@ -230,26 +248,51 @@ func GetArrAsInt(a []int) int {
 	return i
 }
 
-// GetLinesAsIntArr splits each space-delimited string in str into ints,
-// returning a 2D slice of parsed values.
+// GetLinesAsIntArr splits each string in str on single spaces into ints,
+// returning a 2D slice of parsed values. Every space starts a new field, so
+// rows keep their column positions and an empty field, as between two
+// spaces, is 0. Other fields that fail to parse are 0 too, with the error
+// logged. ParseIntLines(str, "") splits on runs of whitespace instead and
+// returns the error.
 func GetLinesAsIntArr(str []string) [][]int {
-	i := make([][]int, 0)
-	for _, s := range str {
-		a := strings.Split(s, " ")
-		i = append(i, GetStrArrAsIntArr(a))
+	return Conv{}.GetLinesAsIntArr(str)
+}
+
+// GetLinesAsIntArr is GetLinesAsIntArr reporting parse errors as c does. In
+// strict mode an empty field is an error too, as it is for ParseIntLines.
+func (c Conv) GetLinesAsIntArr(str []string) [][]int {
+	var opts []IntsOption
+	if !c.Strict {
+		opts = append(opts, EmptyAs(0))
 	}
-	return i
+	ints := make([][]int, 0, len(str))
+	for n, s := range str {
+		row, err := ParseInts(s, " ", opts...)
+		if err != nil {
+			c.fail(atLine(err, n+1, s))
+		}
+		ints = append(ints, row)
+	}
+	return ints
 }
 
 // GetIntArrFromDelimitedStr splits s on delimiter d (handling optional
 // surrounding quotes when quoted is true) and converts the fields to ints.
+// It is ParseInts with errors logged rather than returned; fields that fail
+// to parse are 0.
 func GetIntArrFromDelimitedStr(s string, d string, quoted bool) []int {
+	return Conv{}.GetIntArrFromDelimitedStr(s, d, quoted)
+}
+
+// GetIntArrFromDelimitedStr is GetIntArrFromDelimitedStr reporting parse
+// errors as c does.
+func (c Conv) GetIntArrFromDelimitedStr(s string, d string, quoted bool) []int {
 	if quoted {
-		s = s[1 : len(s)-1]
-		//fmt.Println(s)
-		return GetStrArrAsIntArr(strings.Split(s, "\""+d+"\""))
+		s, d = s[1:len(s)-1], "\""+d+"\""
 	}
-	return GetStrArrAsIntArr(strings.Split(s, d))
+	ints, err := ParseInts(s, d)
+	c.fail(err)
+	return ints
 }
 
 // GetArrFromDelimitedStr splits s on delimiter d, optionally stripping
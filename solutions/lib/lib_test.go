@@ -245,6 +245,11 @@ func TestAnyToInt(t *testing.T) {
 		output := AnyToInt(test.Input)
 		CheckTest(t, "lib.AnyToInt", test, output)
 	}
+	for _, a := range []any{"5", int64(5)} {
+		if r := panicValue(func() { AnyToInt(a) }); r == nil {
+			t.Errorf("expected AnyToInt to panic on %T %v", a, a)
+		}
+	}
 }
 
 func TestRuneToInt(t *testing.T) {
@@ -558,6 +563,11 @@ func TestGetLinesAsIntArr(t *testing.T) {
 			Input:  []string{"1 2 3", "4 5 6", "7 8 9"},
 			Expect: [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
 		},
+		{
+			Name:   "Outside strict mode every space starts a field, so empty fields keep their column as 0",
+			Input:  []string{"1  3", " 2", "4 5 "},
+			Expect: [][]int{{1, 0, 3}, {0, 2}, {4, 5, 0}},
+		},
 	}
 	for _, test := range tests {
 		CheckTest(t, "lib.GetLinesAsIntArr", test, GetLinesAsIntArr(test.Input.([]string)))
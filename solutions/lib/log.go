@@ -0,0 +1,86 @@
+package eulerlib
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strings"
+)
+
+const (
+	// LevelTrace is below slog.LevelDebug, for output too verbose to want
+	// even when debugging, such as every step of a search.
+	LevelTrace = slog.LevelDebug - 4
+	// LevelOff is above every level, so a Debugger set to it logs nothing.
+	LevelOff = slog.Level(math.MaxInt32)
+)
+
+// LogFormat selects how log records are written.
+type LogFormat string
+
+const (
+	// LogText writes records as key=value pairs.
+	LogText LogFormat = "text"
+	// LogJSON writes one JSON object per record.
+	LogJSON LogFormat = "json"
+)
+
+// LogOptions configures a Debugger made by NewLogger. The zero value logs
+// text at info level.
+type LogOptions struct {
+	Level  slog.Level
+	Format LogFormat
+}
+
+// levelNames maps the names accepted by ParseLevel to levels.
+var levelNames = map[string]slog.Level{
+	"trace": LevelTrace,
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+	"off":   LevelOff,
+}
+
+// ParseLevel parses a level name: trace, debug, info, warn, error or off.
+func ParseLevel(s string) (slog.Level, error) {
+	level, ok := levelNames[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown log level %q (want trace, debug, info, warn, error or off)", s)
+	}
+	return level, nil
+}
+
+// ParseLogFormat parses "text" or "json".
+func ParseLogFormat(s string) (LogFormat, error) {
+	switch f := LogFormat(strings.ToLower(s)); f {
+	case LogText, LogJSON:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown log format %q (want text or json)", s)
+}
+
+// NewLogHandler returns a slog handler writing records at or above level to w
+// in the given format; an empty format means LogText. LevelTrace records are
+// labelled TRACE rather than slog's DEBUG-4.
+func NewLogHandler(w io.Writer, format LogFormat, level slog.Leveler) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: replaceLevel}
+	switch format {
+	case LogText, "":
+		return slog.NewTextHandler(w, opts), nil
+	case LogJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q", format)
+}
+
+// replaceLevel names LevelTrace in handler output.
+func replaceLevel(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := a.Value.Any().(slog.Level); ok && level <= LevelTrace {
+			a.Value = slog.StringValue("TRACE")
+		}
+	}
+	return a
+}
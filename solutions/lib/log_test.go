@@ -0,0 +1,53 @@
+package eulerlib
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []TTest{
+		{Name: "trace", Input: "trace", Expect: LevelTrace},
+		{Name: "debug", Input: "DEBUG", Expect: slog.LevelDebug},
+		{Name: "info", Input: "info", Expect: slog.LevelInfo},
+		{Name: "off", Input: "off", Expect: LevelOff},
+	}
+	for _, test := range tests {
+		level, err := ParseLevel(test.Input.(string))
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.Name, err)
+		}
+		CheckTest(t, "log.ParseLevel", test, level)
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func TestParseLogFormat(t *testing.T) {
+	format, err := ParseLogFormat("JSON")
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "log.ParseLogFormat", TTest{Name: "json", Expect: LogJSON}, format)
+	if _, err := ParseLogFormat("xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestNewLogHandler(t *testing.T) {
+	var bf bytes.Buffer
+	handler, err := NewLogHandler(&bf, LogText, LevelTrace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slog.New(handler).Log(t.Context(), LevelTrace, "step")
+	if !strings.Contains(bf.String(), "level=TRACE msg=step") {
+		t.Errorf("expected a TRACE record, got %q", bf.String())
+	}
+	if _, err := NewLogHandler(&bf, "xml", LevelTrace); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,277 @@
+package eulerlib
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AnswersFile is the name of the checked-in answers manifest. It is found by
+// searching upwards from a solution's directory.
+const AnswersFile = "answers.json"
+
+// AnswersEnv names the environment variable holding an explicit path to the
+// answers manifest.
+const AnswersEnv = "AOC_ANSWERS"
+
+// AnswerStatus records how much an answer in the manifest can be trusted.
+type AnswerStatus string
+
+const (
+	// AnswerVerified means the answer was accepted by Advent of Code.
+	AnswerVerified AnswerStatus = "verified"
+	// AnswerUnknown means the correct answer is not known yet, although
+	// some wrong ones may be.
+	AnswerUnknown AnswerStatus = "unknown"
+	// AnswerExample means the answer was given alongside a sample input in
+	// the puzzle description. It is trusted for checking the sample, but was
+	// never submitted.
+	AnswerExample AnswerStatus = "example"
+)
+
+// Trusted reports whether an answer with this status can be checked
+// against.
+func (s AnswerStatus) Trusted() bool {
+	return s == AnswerVerified || s == AnswerExample
+}
+
+// AnswerRecord is the manifest entry for one puzzle input.
+type AnswerRecord struct {
+	Year int `json:"year"`
+	Day  int `json:"day"`
+	Part int `json:"part"`
+	// Input is the HashInput of the input the answer belongs to.
+	Input  string       `json:"input"`
+	Status AnswerStatus `json:"status"`
+	Answer Answer       `json:"answer"`
+	// Wrong lists answers that were submitted and rejected.
+	Wrong []Answer `json:"wrong,omitempty"`
+}
+
+// IsWrong reports whether a is one of the record's rejected answers.
+func (m *AnswerRecord) IsWrong(a Answer) bool {
+	return slices.ContainsFunc(m.Wrong, a.Equal)
+}
+
+// HashInput returns the hex SHA-256 of lines joined by "\n", so that an input
+// hashes the same whatever its line endings.
+func HashInput(lines []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Manifest is the set of known answers, keyed by year, day, part and input
+// hash.
+type Manifest struct {
+	Path    string
+	Records []AnswerRecord
+}
+
+// FindManifest returns the manifest path for a solution in dir: AOC_ANSWERS
+// when it is set, otherwise the nearest answers.json in dir or one of its
+// parents. A relative dir, or "" for an unknown one, is taken from the
+// working directory. It returns "" when there is none.
+func FindManifest(dir string) string {
+	if path := os.Getenv(AnswersEnv); path != "" {
+		return path
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	for dir != "" {
+		path := filepath.Join(dir, AnswersFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return ""
+}
+
+// LoadManifest reads the manifest at path. A missing file gives an empty
+// manifest that Save will create.
+func LoadManifest(path string) (*Manifest, error) {
+	m := &Manifest{Path: path, Records: []AnswerRecord{}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &m.Records); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Save writes the manifest back to its Path, ordered by year, day and part.
+func (m *Manifest) Save() error {
+	slices.SortStableFunc(m.Records, func(a, b AnswerRecord) int {
+		return cmp.Or(cmp.Compare(a.Year, b.Year), cmp.Compare(a.Day, b.Day), cmp.Compare(a.Part, b.Part))
+	})
+	b, err := json.MarshalIndent(m.Records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.Path, append(b, '\n'), 0o644)
+}
+
+// Lookup returns the record for an input of the given puzzle.
+func (m *Manifest) Lookup(year, day, part int, hash string) (*AnswerRecord, bool) {
+	for i := range m.Records {
+		r := &m.Records[i]
+		if r.Year == year && r.Day == day && r.Part == part && r.Input == hash {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// record returns the record for an input, adding an unknown one if needed.
+func (m *Manifest) record(year, day, part int, hash string) *AnswerRecord {
+	if r, ok := m.Lookup(year, day, part, hash); ok {
+		return r
+	}
+	m.Records = append(m.Records, AnswerRecord{Year: year, Day: day, Part: part, Input: hash, Status: AnswerUnknown})
+	return &m.Records[len(m.Records)-1]
+}
+
+// Verify records answer as the accepted answer for an input. It refuses an
+// answer already recorded as wrong.
+func (m *Manifest) Verify(year, day, part int, hash string, answer Answer) error {
+	if answer.IsEmpty() {
+		return errors.New("cannot verify an empty answer")
+	}
+	r := m.record(year, day, part, hash)
+	if r.IsWrong(answer) {
+		return fmt.Errorf("%s was recorded as a wrong answer for day %d part %d", answer, day, part)
+	}
+	r.Status = AnswerVerified
+	r.Answer = answer
+	return nil
+}
+
+// RecordExample records answer as the puzzle description's answer for a
+// sample input. It leaves a matching verified answer as it is, and refuses
+// an answer that contradicts a verified or wrong one.
+func (m *Manifest) RecordExample(year, day, part int, hash string, answer Answer) error {
+	if answer.IsEmpty() {
+		return errors.New("cannot record an empty example answer")
+	}
+	r := m.record(year, day, part, hash)
+	if r.IsWrong(answer) {
+		return fmt.Errorf("%s was recorded as a wrong answer for day %d part %d", answer, day, part)
+	}
+	if r.Status == AnswerVerified {
+		if r.Answer.Equal(answer) {
+			return nil
+		}
+		return fmt.Errorf("%s differs from the verified answer %s for day %d part %d", answer, r.Answer, day, part)
+	}
+	r.Status = AnswerExample
+	r.Answer = answer
+	return nil
+}
+
+// AddWrong records answer as rejected for an input. It refuses the verified
+// answer.
+func (m *Manifest) AddWrong(year, day, part int, hash string, answer Answer) error {
+	r := m.record(year, day, part, hash)
+	if r.Status == AnswerVerified && r.Answer.Equal(answer) {
+		return fmt.Errorf("%s is the verified answer for day %d part %d", answer, day, part)
+	}
+	if !r.IsWrong(answer) {
+		r.Wrong = append(r.Wrong, answer)
+	}
+	return nil
+}
+
+// Forget marks an input's answer as unknown again, keeping its wrong answers.
+func (m *Manifest) Forget(year, day, part int, hash string) {
+	r := m.record(year, day, part, hash)
+	r.Status = AnswerUnknown
+	r.Answer = Answer{}
+}
+
+// Expectation is the answer a run is checked against and, when it came from
+// the manifest, the record it came from.
+type Expectation struct {
+	Answer Answer
+	Record *AnswerRecord
+}
+
+// Known reports whether there is a trusted answer to compare against.
+func (m Expectation) Known() bool {
+	return !m.Answer.IsEmpty() && (m.Record == nil || m.Record.Status.Trusted())
+}
+
+// IsWrong reports whether answer is a known wrong submission.
+func (m Expectation) IsWrong(answer Answer) bool {
+	return m.Record != nil && m.Record.IsWrong(answer)
+}
+
+// expect returns the Expectation for problem's full or short input found
+// through search: the manifest record for that input when there is one, and
+// GetAnswer or GetShortAnswer otherwise. Manifest problems are reported
+// rather than hidden, since a broken manifest would otherwise silently
+// downgrade verified answers.
+func expect(problem Problem, search InputSearch, filename string, short bool) (Expectation, error) {
+	var e Expectation
+	if short {
+		e.Answer = ParseAnswer(shortAnswer(problem))
+	} else {
+		e.Answer = ParseAnswer(problem.GetAnswer())
+	}
+	path := FindManifest(search.Dir)
+	if path == "" {
+		return e, nil
+	}
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		return e, err
+	}
+	year, day, part, ok := problemPuzzle(problem, search)
+	if !ok {
+		return e, nil
+	}
+	lines, err := search.Load(filename)
+	if err != nil {
+		//the solution will report the missing input itself
+		return e, nil
+	}
+	if r, ok := manifest.Lookup(year, day, part, HashInput(lines)); ok {
+		e.Answer = r.Answer
+		e.Record = r
+	}
+	return e, nil
+}
+
+// problemPuzzle works out which puzzle a problem solves from its "Day X,
+// Part Y" name.
+func problemPuzzle(problem Problem, search InputSearch) (year, day, part int, ok bool) {
+	match := problemNameRe.FindStringSubmatch(problem.GetProblemName())
+	if match == nil {
+		return 0, 0, 0, false
+	}
+	day, _ = strconv.Atoi(match[1])
+	part, _ = strconv.Atoi(match[2])
+	year = search.Year
+	if year == 0 {
+		year = DefaultYear
+	}
+	return year, day, part, true
+}
@@ -0,0 +1,223 @@
+package eulerlib
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHashInput(t *testing.T) {
+	crlf, _ := os.CreateTemp(t.TempDir(), "input")
+	crlf.WriteString("a\r\nb\r\n")
+	crlf.Close()
+	lines, _ := ReadLines(crlf.Name())
+	CheckTest(t, "manifest.HashInput", TTest{Name: "line endings ignored", Expect: HashInput([]string{"a", "b"})}, HashInput(lines))
+	CheckTest(t, "manifest.HashInput", TTest{Name: "length", Expect: 64}, len(HashInput(nil)))
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), AnswersFile)
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddWrong(2025, 3, 1, "h3", IntAnswer(5)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Verify(2025, 1, 2, "h1", IntAnswer(6099)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "manifest.Save", TTest{Name: "sorted", Expect: 1}, loaded.Records[0].Day)
+	r, ok := loaded.Lookup(2025, 1, 2, "h1")
+	CheckTest(t, "manifest.Lookup", TTest{Name: "verified", Expect: true}, ok && r.Status == AnswerVerified && r.Answer.Equal(IntAnswer(6099)))
+	r, ok = loaded.Lookup(2025, 3, 1, "h3")
+	CheckTest(t, "manifest.Lookup", TTest{Name: "unknown with wrong", Expect: true}, ok && r.Status == AnswerUnknown && r.IsWrong(ParseAnswer("5")))
+	_, ok = loaded.Lookup(2025, 1, 2, "other input")
+	CheckTest(t, "manifest.Lookup", TTest{Name: "different input", Expect: false}, ok)
+}
+
+func TestManifestGuards(t *testing.T) {
+	m := &Manifest{}
+	m.AddWrong(2025, 1, 1, "h", IntAnswer(7))
+	if err := m.Verify(2025, 1, 1, "h", IntAnswer(7)); err == nil || !strings.Contains(err.Error(), "recorded as a wrong answer") {
+		t.Errorf("expected verifying a wrong answer to fail, got %v", err)
+	}
+	m.Verify(2025, 1, 1, "h", IntAnswer(8))
+	if err := m.AddWrong(2025, 1, 1, "h", IntAnswer(8)); err == nil {
+		t.Error("expected marking the verified answer wrong to fail")
+	}
+	m.AddWrong(2025, 1, 1, "h", IntAnswer(7))
+	r, _ := m.Lookup(2025, 1, 1, "h")
+	CheckTest(t, "manifest.AddWrong", TTest{Name: "no duplicates", Expect: 1}, len(r.Wrong))
+	m.Forget(2025, 1, 1, "h")
+	CheckTest(t, "manifest.Forget", TTest{Name: "status", Expect: AnswerUnknown}, r.Status)
+	if err := m.Verify(2025, 1, 1, "h", Answer{}); err == nil {
+		t.Error("expected verifying an empty answer to fail")
+	}
+}
+
+func TestManifestRecordExample(t *testing.T) {
+	m := &Manifest{}
+	if err := m.RecordExample(2025, 1, 1, "sample", IntAnswer(3)); err != nil {
+		t.Fatal(err)
+	}
+	r, _ := m.Lookup(2025, 1, 1, "sample")
+	CheckTest(t, "manifest.RecordExample", TTest{Name: "status", Expect: AnswerExample}, r.Status)
+	CheckTest(t, "manifest.RecordExample", TTest{Name: "trusted", Expect: true}, Expectation{Answer: r.Answer, Record: r}.Known())
+
+	//a verified answer is kept, and never contradicted
+	m.Verify(2025, 1, 2, "sample", IntAnswer(4))
+	m.RecordExample(2025, 1, 2, "sample", IntAnswer(4))
+	r, _ = m.Lookup(2025, 1, 2, "sample")
+	CheckTest(t, "manifest.RecordExample", TTest{Name: "keeps verified", Expect: AnswerVerified}, r.Status)
+	if err := m.RecordExample(2025, 1, 2, "sample", IntAnswer(5)); err == nil {
+		t.Error("expected an example answer differing from the verified one to fail")
+	}
+	m.AddWrong(2025, 1, 1, "other", IntAnswer(6))
+	if err := m.RecordExample(2025, 1, 1, "other", IntAnswer(6)); err == nil {
+		t.Error("expected a wrong example answer to fail")
+	}
+}
+
+func TestFindManifest(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "day1-1")
+	os.MkdirAll(dir, 0o755)
+	t.Setenv(AnswersEnv, "")
+	CheckTest(t, "manifest.FindManifest", TTest{Name: "none", Expect: ""}, FindManifest(dir))
+	os.WriteFile(filepath.Join(root, AnswersFile), []byte("[]"), 0o644)
+	CheckTest(t, "manifest.FindManifest", TTest{Name: "parent", Expect: filepath.Join(root, AnswersFile)}, FindManifest(dir))
+	t.Setenv(AnswersEnv, "/elsewhere/answers.json")
+	CheckTest(t, "manifest.FindManifest", TTest{Name: "env", Expect: "/elsewhere/answers.json"}, FindManifest(dir))
+}
+
+// TUnknownProblem has no known answer for its input, which has the answer 2.
+type TUnknownProblem struct {
+	Problem
+}
+
+func (m *TUnknownProblem) GetProblemName() string { return "Day 1, Part 1" }
+
+func (m *TUnknownProblem) GetAnswer() string { return "" }
+
+func (m *TUnknownProblem) GetShortAnswer() string { return "" }
+
+func (m *TUnknownProblem) GenerateAnswer() string { return "2" }
+
+// manifestDir returns a day1-1 solution directory holding a one line input
+// and an answers manifest in its parent.
+func manifestDir(t *testing.T, record func(m *Manifest, hash string)) InputSearch {
+	root := t.TempDir()
+	dir := filepath.Join(root, "day1-1")
+	os.MkdirAll(dir, 0o755)
+	os.WriteFile(filepath.Join(dir, DefaultInput), []byte("x\n"), 0o644)
+	m, _ := LoadManifest(filepath.Join(root, AnswersFile))
+	record(m, HashInput([]string{"x"}))
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(AnswersEnv, "")
+	return inputSearchForDir(dir)
+}
+
+func TestTestProblemSkipsUnknownAnswer(t *testing.T) {
+	search := manifestDir(t, func(m *Manifest, hash string) { m.Forget(2025, 1, 1, hash) })
+	var inner *testing.T
+	t.Run("unknown", func(t *testing.T) {
+		inner = t
+		testProblem(&TUnknownProblem{}, t, 0, search)
+	})
+	CheckTest(t, "problem.TestProblem", TTest{Name: "skipped", Expect: true}, inner.Skipped())
+}
+
+func TestTestProblemUsesManifest(t *testing.T) {
+	search := manifestDir(t, func(m *Manifest, hash string) { m.Verify(2025, 1, 1, hash, IntAnswer(2)) })
+	testProblem(&TUnknownProblem{}, t, 0, search)
+
+	search = manifestDir(t, func(m *Manifest, hash string) { m.AddWrong(2025, 1, 1, hash, IntAnswer(2)) })
+	expect, err := expect(&TUnknownProblem{}, search, DefaultInput, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "manifest.expect", TTest{Name: "wrong", Expect: true}, expect.IsWrong(IntAnswer(2)))
+	CheckTest(t, "manifest.expect", TTest{Name: "known", Expect: false}, expect.Known())
+}
+
+// TScaffoldProblem is a day as aoc new leaves it: no answers of its own and a
+// solver that always gives 0.
+type TScaffoldProblem struct {
+	Problem
+}
+
+func (m *TScaffoldProblem) GetProblemName() string { return "Day 1, Part 1" }
+
+func (m *TScaffoldProblem) GetAnswer() string { return "" }
+
+func (m *TScaffoldProblem) GetShortAnswer() string { return "" }
+
+func (m *TScaffoldProblem) GenerateShortAnswer() string { return "0" }
+
+// exampleDir returns a day1-1 solution directory whose sample input has the
+// example answer recorded in the answers manifest, as aoc examples leaves it.
+func exampleDir(t *testing.T, answer Answer) InputSearch {
+	sample := []string{"s"}
+	search := manifestDir(t, func(m *Manifest, hash string) {
+		m.RecordExample(2025, 1, 1, HashInput(sample), answer)
+	})
+	os.WriteFile(filepath.Join(search.Dir, DefaultShortInput), []byte("s\n"), 0o644)
+	return search
+}
+
+func TestTestProblemUsesExampleRecord(t *testing.T) {
+	search := exampleDir(t, IntAnswer(0))
+	CheckTest(t, "problem.useShort", TTest{Name: "example recorded", Expect: true}, useShort(&TScaffoldProblem{}, search))
+	var inner *testing.T
+	t.Run("right", func(t *testing.T) {
+		inner = t
+		testProblem(&TScaffoldProblem{}, t, 0, search)
+	})
+	CheckTest(t, "problem.TestProblem", TTest{Name: "checked", Expect: false}, inner.Skipped())
+
+	//a wrong answer must fail, so check it in a child test binary
+	if os.Getenv("AOC_TEST_WRONG_EXAMPLE") == "1" {
+		testProblem(&TScaffoldProblem{}, t, 0, exampleDir(t, IntAnswer(3)))
+		return
+	}
+	bin, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(bin, "-test.run=^TestTestProblemUsesExampleRecord$")
+	cmd.Env = append(os.Environ(), "AOC_TEST_WRONG_EXAMPLE=1")
+	out, err := cmd.CombinedOutput()
+	if err == nil || !strings.Contains(string(out), "expected was 3, output was 0") {
+		t.Errorf("expected a wrong short answer to fail against the example record, got %v:\n%s", err, out)
+	}
+}
+
+func TestRunResultStatus(t *testing.T) {
+	verified := &AnswerRecord{Status: AnswerVerified, Answer: IntAnswer(2)}
+	unknown := &AnswerRecord{Status: AnswerUnknown, Wrong: []Answer{IntAnswer(3)}}
+	tests := []TTest{
+		{Name: "pass", Input: RunResult{Answer: IntAnswer(2), Expected: IntAnswer(2), Record: verified}, Expect: "pass"},
+		{Name: "fail", Input: RunResult{Answer: IntAnswer(1), Expected: IntAnswer(2), Record: verified}, Expect: "FAIL"},
+		{Name: "unknown", Input: RunResult{Answer: IntAnswer(1), Record: unknown}, Expect: "unknown"},
+		{Name: "no answer", Input: RunResult{Answer: IntAnswer(1)}, Expect: "unknown"},
+		{Name: "wrong", Input: RunResult{Answer: IntAnswer(3), Record: unknown}, Expect: "WRONG"},
+	}
+	for _, test := range tests {
+		r := test.Input.(RunResult)
+		CheckTest(t, "runner.Status", test, r.Status())
+		CheckTest(t, "runner.Failed", TTest{Name: test.Name, Expect: test.Expect == "FAIL" || test.Expect == "WRONG"}, r.Failed())
+	}
+}
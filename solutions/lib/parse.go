@@ -0,0 +1,474 @@
+package eulerlib
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseError reports input that could not be parsed, with the 1-based line
+// and column it was found at. Line is zero when the input was a single
+// string rather than a line of a file, and Col is zero when the whole line
+// is at fault.
+type ParseError struct {
+	Line int
+	Col  int
+	// Text is the line being parsed.
+	Text string
+	Msg  string
+	// Err is the underlying error, such as a strconv range error.
+	Err error
+}
+
+// Error formats the position, message and offending text, such as
+// `line 3, col 5: expected an integer in "L12x"`.
+func (e *ParseError) Error() string {
+	var pos []string
+	if e.Line > 0 {
+		pos = append(pos, fmt.Sprintf("line %d", e.Line))
+	}
+	if e.Col > 0 {
+		pos = append(pos, fmt.Sprintf("col %d", e.Col))
+	}
+	msg := e.Msg
+	if msg == "" && e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if len(pos) > 0 {
+		msg = strings.Join(pos, ", ") + ": " + msg
+	}
+	return fmt.Sprintf("%s in %q", msg, e.Text)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// atLine sets the line number of a ParseError, or wraps any other error in
+// one for that line.
+func atLine(err error, line int, text string) error {
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		perr.Line = line
+		return perr
+	}
+	return &ParseError{Line: line, Text: text, Err: err}
+}
+
+// Section is a run of lines separated from the rest of the input by blank
+// lines.
+type Section struct {
+	// Line is the 1-based line number of the section's first line.
+	Line  int
+	Lines []string
+}
+
+// SplitSections splits lines into the sections between blank lines. Runs of
+// blank lines, and blank lines at either end, separate nothing.
+func SplitSections(lines []string) []Section {
+	var sections []Section
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if i == 0 || strings.TrimSpace(lines[i-1]) == "" {
+			sections = append(sections, Section{Line: i + 1})
+		}
+		last := &sections[len(sections)-1]
+		last.Lines = append(last.Lines, line)
+	}
+	return sections
+}
+
+// SplitParagraphs splits text into its blank-line separated paragraphs, each
+// with its lines joined by "\n".
+func SplitParagraphs(s string) []string {
+	var paragraphs []string
+	for _, section := range SplitSections(SplitLines(s)) {
+		paragraphs = append(paragraphs, strings.Join(section.Lines, "\n"))
+	}
+	return paragraphs
+}
+
+// ParseLines calls parse on each line, returning the results or the first
+// error annotated with its line number.
+func ParseLines[T any](lines []string, parse func(line string) (T, error)) ([]T, error) {
+	values := make([]T, 0, len(lines))
+	for i, line := range lines {
+		v, err := parse(line)
+		if err != nil {
+			return values, atLine(err, i+1, line)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// field is a piece of a delimited string and the byte offset it starts at.
+type field struct {
+	text   string
+	offset int
+}
+
+// splitFields splits s on sep, or into whitespace-separated fields when sep
+// is empty, recording where each field starts.
+func splitFields(s, sep string) []field {
+	var fields []field
+	if sep == "" {
+		start := -1
+		for i, r := range s {
+			if unicode.IsSpace(r) {
+				if start >= 0 {
+					fields = append(fields, field{s[start:i], start})
+					start = -1
+				}
+			} else if start < 0 {
+				start = i
+			}
+		}
+		if start >= 0 {
+			fields = append(fields, field{s[start:], start})
+		}
+		return fields
+	}
+	offset := 0
+	for {
+		i := strings.Index(s[offset:], sep)
+		if i < 0 {
+			return append(fields, field{s[offset:], offset})
+		}
+		fields = append(fields, field{s[offset : offset+i], offset})
+		offset += i + len(sep)
+	}
+}
+
+// IntsOption changes how ParseInts and ParseIntLines read their fields.
+type IntsOption func(*intsConfig)
+
+// intsConfig is what the IntsOptions given to ParseInts set.
+type intsConfig struct {
+	empty    int
+	useEmpty bool
+}
+
+// EmptyAs makes ParseInts read an empty field, such as between two
+// separators in a row, as v rather than reporting it as an error. With a
+// single space as the separator this keeps the fields of space-aligned
+// columns in place.
+func EmptyAs(v int) IntsOption {
+	return func(c *intsConfig) {
+		c.empty, c.useEmpty = v, true
+	}
+}
+
+// ParseInts parses a list of decimal integers separated by sep, or by
+// whitespace when sep is empty. Space around each integer is ignored. On
+// error the integers that could not be parsed are 0 in the result and the
+// error reports the column of the first.
+func ParseInts(s, sep string, opts ...IntsOption) ([]int, error) {
+	var config intsConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	fields := splitFields(s, sep)
+	ints := make([]int, len(fields))
+	var first error
+	for i, f := range fields {
+		trimmed := strings.TrimLeftFunc(f.text, unicode.IsSpace)
+		col := f.offset + len(f.text) - len(trimmed) + 1
+		trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+		if trimmed == "" && config.useEmpty {
+			ints[i] = config.empty
+			continue
+		}
+		v, err := strconv.Atoi(trimmed)
+		if err != nil && first == nil {
+			first = &ParseError{Col: col, Text: s, Msg: fmt.Sprintf("expected an integer, got %q", trimmed), Err: errors.Unwrap(err)}
+		}
+		ints[i] = v
+	}
+	return ints, first
+}
+
+// ParseIntLines parses each line as a list of integers separated by sep, as
+// ParseInts does, reporting the line and column of the first error.
+func ParseIntLines(lines []string, sep string, opts ...IntsOption) ([][]int, error) {
+	return ParseLines(lines, func(line string) ([]int, error) {
+		return ParseInts(line, sep, opts...)
+	})
+}
+
+// intRe matches a signed decimal integer.
+var intRe = regexp.MustCompile(`[+-]?\d+`)
+
+// FindInts returns every integer in s, ignoring whatever surrounds them, so
+// "x=-3, y=12" gives [-3 12]. A '-' directly before digits is a sign.
+func FindInts(s string) []int {
+	matches := intRe.FindAllString(s, -1)
+	ints := make([]int, 0, len(matches))
+	for _, match := range matches {
+		v, _ := strconv.Atoi(match)
+		ints = append(ints, v)
+	}
+	return ints
+}
+
+// patternItem is one literal run or verb of a Pattern.
+type patternItem struct {
+	// verb is 0 for literal text.
+	verb rune
+	// expr is the regular expression the item matches.
+	expr string
+	// sep is the separator of a list verb such as %[,]d.
+	sep string
+	// desc describes the item for errors, such as "an integer" or `"->"`.
+	desc string
+}
+
+// Pattern is a compiled Scanf pattern. Text in the pattern must appear in the
+// input exactly, except that a run of spaces matches any run of spaces and
+// tabs. The verbs capture typed values into the pointers passed to Scan:
+//
+//	%d      a decimal integer, into *int, *int64 or *string
+//	%x      a hexadecimal integer, into *int, *int64 or *string
+//	%w      a word of letters, digits and underscores, into *string
+//	%s      the shortest text up to whatever follows, into *string
+//	%c      a single character, into *rune, *byte or *string
+//	%[sep]d a list of decimal integers separated by sep, into *[]int
+//	%%      a literal percent sign
+//
+// So "%w: %[,]d" matches "abc: 1,2,3". A Pattern is safe for concurrent use.
+type Pattern struct {
+	src   string
+	items []patternItem
+	re    *regexp.Regexp
+}
+
+// CompilePattern parses a Scanf pattern.
+func CompilePattern(pattern string) (*Pattern, error) {
+	m := &Pattern{src: pattern}
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			text := literal.String()
+			parts := spacesRe.Split(text, -1)
+			for i, part := range parts {
+				parts[i] = regexp.QuoteMeta(part)
+			}
+			expr := strings.Join(parts, `[ \t]+`)
+			m.items = append(m.items, patternItem{expr: expr, desc: strconv.Quote(text)})
+			literal.Reset()
+		}
+	}
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c != '%' {
+			literal.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(pattern) {
+			return nil, fmt.Errorf("pattern %q ends with %%", pattern)
+		}
+		if pattern[i] == '%' {
+			literal.WriteByte('%')
+			continue
+		}
+		flush()
+		item := patternItem{verb: rune(pattern[i])}
+		if pattern[i] == '[' {
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 || i+end+1 >= len(pattern) || pattern[i+end+1] != 'd' {
+				return nil, fmt.Errorf("pattern %q: a list must be written %%[sep]d", pattern)
+			}
+			item.verb, item.sep = 'l', pattern[i+1:i+end]
+			i += end + 1
+		}
+		switch item.verb {
+		case 'd':
+			item.expr, item.desc = `[+-]?\d+`, "an integer"
+		case 'x':
+			item.expr, item.desc = `[0-9a-fA-F]+`, "a hexadecimal integer"
+		case 'w':
+			item.expr, item.desc = `\w+`, "a word"
+		case 's':
+			item.expr, item.desc = `.+?`, "some text"
+		case 'c':
+			item.expr, item.desc = `.`, "a character"
+		case 'l':
+			item.expr = `[+-]?\d+(?:` + regexp.QuoteMeta(item.sep) + `[+-]?\d+)*`
+			item.desc = fmt.Sprintf("a list of integers separated by %q", item.sep)
+		default:
+			return nil, fmt.Errorf("pattern %q: unknown verb %%%c", pattern, item.verb)
+		}
+		m.items = append(m.items, item)
+	}
+	flush()
+	var expr strings.Builder
+	for _, item := range m.items {
+		if item.verb == 0 {
+			expr.WriteString(item.expr)
+		} else {
+			expr.WriteString("(" + item.expr + ")")
+		}
+	}
+	re, err := regexp.Compile("^" + expr.String() + "$")
+	if err != nil {
+		return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+	m.re = re
+	return m, nil
+}
+
+// MustCompilePattern is like CompilePattern but panics if the pattern is
+// invalid. It suits patterns held in package variables.
+func MustCompilePattern(pattern string) *Pattern {
+	m, err := CompilePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// String returns the source of the pattern.
+func (m *Pattern) String() string {
+	return m.src
+}
+
+// Scan matches the whole of s against the pattern and stores each captured
+// value in the matching argument. A mismatch is reported as a ParseError at
+// the column where matching failed, naming what was expected there.
+func (m *Pattern) Scan(s string, args ...any) error {
+	verbs := 0
+	for _, item := range m.items {
+		if item.verb != 0 {
+			verbs++
+		}
+	}
+	if verbs != len(args) {
+		return fmt.Errorf("pattern %q has %d verbs but %d arguments were given", m.src, verbs, len(args))
+	}
+	match := m.re.FindStringSubmatchIndex(s)
+	if match == nil {
+		return m.mismatch(s)
+	}
+	for i, arg := range args {
+		start, end := match[2*i+2], match[2*i+3]
+		if err := m.store(m.captureItem(i), s[start:end], arg); err != nil {
+			col := utf8.RuneCountInString(s[:start]) + 1
+			var perr *ParseError
+			if errors.As(err, &perr) {
+				//a list reports its column within the captured text
+				return &ParseError{Col: col + perr.Col - 1, Text: s, Msg: perr.Msg, Err: perr.Err}
+			}
+			return &ParseError{Col: col, Text: s, Msg: err.Error(), Err: errors.Unwrap(err)}
+		}
+	}
+	return nil
+}
+
+// captureItem returns the i'th verb of the pattern.
+func (m *Pattern) captureItem(i int) patternItem {
+	for _, item := range m.items {
+		if item.verb != 0 {
+			if i == 0 {
+				return item
+			}
+			i--
+		}
+	}
+	return patternItem{}
+}
+
+// mismatch explains why s does not match: the longest run of items that
+// does match, and what the next item expected.
+func (m *Pattern) mismatch(s string) error {
+	prefix, end := "^", 0
+	for _, item := range m.items {
+		expr := item.expr
+		if item.verb != 0 {
+			expr = "(?:" + expr + ")"
+		}
+		//match the items so far greedily, except where the next one must end
+		loc := regexp.MustCompile(prefix + expr).FindStringIndex(s)
+		if loc == nil {
+			return &ParseError{Col: utf8.RuneCountInString(s[:end]) + 1, Text: s, Msg: fmt.Sprintf("expected %s", item.desc)}
+		}
+		prefix += expr
+		end = loc[1]
+	}
+	return &ParseError{Col: utf8.RuneCountInString(s[:end]) + 1, Text: s, Msg: fmt.Sprintf("unexpected %q after the pattern %q", s[end:], m.src)}
+}
+
+// store converts a captured value for an item and assigns it to arg.
+func (m *Pattern) store(item patternItem, value string, arg any) error {
+	base := 10
+	if item.verb == 'x' {
+		base = 16
+	}
+	switch p := arg.(type) {
+	case *string:
+		*p = value
+		return nil
+	case *int:
+		if item.verb == 'd' || item.verb == 'x' {
+			v, err := strconv.ParseInt(value, base, strconv.IntSize)
+			*p = int(v)
+			return intError(value, err)
+		}
+	case *int64:
+		if item.verb == 'd' || item.verb == 'x' {
+			v, err := strconv.ParseInt(value, base, 64)
+			*p = v
+			return intError(value, err)
+		}
+	case *rune:
+		if item.verb == 'c' {
+			*p, _ = utf8.DecodeRuneInString(value)
+			return nil
+		}
+	case *byte:
+		if item.verb == 'c' && len(value) == 1 {
+			*p = value[0]
+			return nil
+		}
+	case *[]int:
+		if item.verb == 'l' {
+			ints, err := ParseInts(value, item.sep)
+			*p = ints
+			return err
+		}
+	}
+	return fmt.Errorf("cannot store %s in %T", item.desc, arg)
+}
+
+// intError describes a failed integer conversion of value.
+func intError(value string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("integer %s out of range: %w", value, errors.Unwrap(err))
+}
+
+// patterns caches the patterns compiled by Scanf.
+var patterns sync.Map
+
+// Scanf matches s against pattern, as Pattern.Scan does, compiling and
+// caching the pattern on first use.
+func Scanf(s, pattern string, args ...any) error {
+	if cached, ok := patterns.Load(pattern); ok {
+		return cached.(*Pattern).Scan(s, args...)
+	}
+	p, err := CompilePattern(pattern)
+	if err != nil {
+		return err
+	}
+	patterns.Store(pattern, p)
+	return p.Scan(s, args...)
+}
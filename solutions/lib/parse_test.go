@@ -0,0 +1,176 @@
+package eulerlib
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSplitSections(t *testing.T) {
+	lines := []string{"", "0:", "###", "", "", "1:", "#..", "", "4x4: 0 1"}
+	sections := SplitSections(lines)
+	CheckTest(t, "parse.SplitSections", TTest{Name: "sections", Expect: []Section{
+		{Line: 2, Lines: []string{"0:", "###"}},
+		{Line: 6, Lines: []string{"1:", "#.."}},
+		{Line: 9, Lines: []string{"4x4: 0 1"}},
+	}}, sections)
+	CheckTest(t, "parse.SplitSections", TTest{Name: "no input", Expect: 0}, len(SplitSections(nil)))
+	CheckTest(t, "parse.SplitParagraphs", TTest{Name: "paragraphs", Expect: []string{"a\nb", "c"}}, SplitParagraphs("a\nb\n\nc\n"))
+}
+
+func TestParseInts(t *testing.T) {
+	tests := []struct {
+		name   string
+		s, sep string
+		expect []int
+		col    int
+	}{
+		{"commas", "1,2,-3", ",", []int{1, 2, -3}, 0},
+		{"spaced commas", "1, 2 ,3", ",", []int{1, 2, 3}, 0},
+		{"whitespace", "  10 20\t30 ", "", []int{10, 20, 30}, 0},
+		{"multi-byte separator", "1 -> 2", "->", []int{1, 2}, 0},
+		{"bad field", "1,x,3", ",", []int{1, 0, 3}, 3},
+		{"empty field", "1,,3", ",", []int{1, 0, 3}, 3},
+		{"bad whitespace field", "1 2 3a", "", []int{1, 2, 0}, 5},
+	}
+	for _, test := range tests {
+		ints, err := ParseInts(test.s, test.sep)
+		CheckTest(t, "parse.ParseInts", TTest{Name: test.name, Expect: test.expect}, ints)
+		col := 0
+		var perr *ParseError
+		if errors.As(err, &perr) {
+			col = perr.Col
+		}
+		CheckTest(t, "parse.ParseInts", TTest{Name: test.name + " error column", Expect: test.col}, col)
+	}
+
+	ints, err := ParseInts("1,,3, ", ",", EmptyAs(-1))
+	CheckTest(t, "parse.ParseInts", TTest{Name: "EmptyAs", Expect: []int{1, -1, 3, -1}}, ints)
+	if err != nil {
+		t.Errorf("expected no error for empty fields with EmptyAs, got %v", err)
+	}
+	_, err = ParseInts("1,,x", ",", EmptyAs(0))
+	CheckTest(t, "parse.ParseInts", TTest{Name: "EmptyAs still reports bad fields", Expect: `col 4: expected an integer, got "x" in "1,,x"`}, err.Error())
+
+	_, err = ParseInts("1,99999999999999999999", ",")
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("expected a range error, got %v", err)
+	}
+}
+
+func TestParseIntLines(t *testing.T) {
+	ints, err := ParseIntLines([]string{"1 2", "3 4"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "parse.ParseIntLines", TTest{Name: "lines", Expect: [][]int{{1, 2}, {3, 4}}}, ints)
+
+	_, err = ParseIntLines([]string{"1 2", "3 4", "5 six"}, "")
+	CheckTest(t, "parse.ParseIntLines", TTest{Name: "error", Expect: `line 3, col 3: expected an integer, got "six" in "5 six"`}, err.Error())
+}
+
+func TestParseLines(t *testing.T) {
+	type move struct {
+		dir   rune
+		steps int
+	}
+	parse := func(line string) (move, error) {
+		var m move
+		return m, Scanf(line, "%c%d", &m.dir, &m.steps)
+	}
+	moves, err := ParseLines([]string{"L68", "R48"}, parse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "parse.ParseLines", TTest{Name: "moves", Expect: []move{{'L', 68}, {'R', 48}}}, moves)
+
+	_, err = ParseLines([]string{"L68", "R4x"}, parse)
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a ParseError, got %v", err)
+	}
+	CheckTest(t, "parse.ParseLines", TTest{Name: "error position", Expect: []int{2, 3}}, []int{perr.Line, perr.Col})
+}
+
+func TestFindInts(t *testing.T) {
+	CheckTest(t, "parse.FindInts", TTest{Name: "mixed", Expect: []int{-3, 12, 7}}, FindInts("x=-3, y=12 at 7s"))
+	CheckTest(t, "parse.FindInts", TTest{Name: "none", Expect: []int{}}, FindInts("none here"))
+}
+
+func TestScanf(t *testing.T) {
+	var name, rest string
+	var x, y int
+	var big int64
+	var c rune
+	var list []int
+	if err := Scanf("aaa: you  hhh", "%w: %s", &name, &rest); err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "parse.Scanf", TTest{Name: "word and text", Expect: []string{"aaa", "you  hhh"}}, []string{name, rest})
+	if err := Scanf("12x5: 1,2,-3", "%dx%d: %[,]d", &x, &y, &list); err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "parse.Scanf", TTest{Name: "dimensions", Expect: []int{12, 5}}, []int{x, y})
+	CheckTest(t, "parse.Scanf", TTest{Name: "list", Expect: []int{1, 2, -3}}, list)
+	if err := Scanf("#ff 100% c", "#%x 100%% %c", &big, &c); err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "parse.Scanf", TTest{Name: "hex", Expect: int64(255)}, big)
+	CheckTest(t, "parse.Scanf", TTest{Name: "char", Expect: 'c'}, c)
+	if err := Scanf("a -> b", "%s -> %s", &name, &rest); err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "parse.Scanf", TTest{Name: "lazy text", Expect: []string{"a", "b"}}, []string{name, rest})
+	if err := Scanf("move   3", "move %d", &x); err != nil {
+		t.Errorf("expected a run of spaces to match, got %v", err)
+	}
+}
+
+func TestScanfErrors(t *testing.T) {
+	var x, y int
+	var list []int
+	tests := []struct {
+		name, s, pattern string
+		args             []any
+		expect           string
+	}{
+		{"literal", "12y5", "%dx%d", []any{&x, &y}, `col 3: expected "x" in "12y5"`},
+		{"verb", "12xy", "%dx%d", []any{&x, &y}, `col 4: expected an integer in "12xy"`},
+		{"trailing", "12x5!", "%dx%d", []any{&x, &y}, `col 5: unexpected "!" after the pattern "%dx%d" in "12x5!"`},
+		{"range", "99999999999999999999", "%d", []any{&x}, `col 1: integer 99999999999999999999 out of range: value out of range in "99999999999999999999"`},
+		{"list", "a: 1,2,99999999999999999999", "a: %[,]d", []any{&list}, `col 8: expected an integer, got "99999999999999999999" in "a: 1,2,99999999999999999999"`},
+		{"argument count", "1", "%d", nil, `pattern "%d" has 1 verbs but 0 arguments were given`},
+		{"argument type", "1", "%d", []any{&list}, `col 1: cannot store an integer in *[]int in "1"`},
+		{"unknown verb", "1", "%q", []any{&x}, `pattern "%q": unknown verb %q`},
+		{"bad list", "1", "%[,", []any{&list}, `pattern "%[,": a list must be written %[sep]d`},
+	}
+	for _, test := range tests {
+		err := Scanf(test.s, test.pattern, test.args...)
+		got := "<nil>"
+		if err != nil {
+			got = err.Error()
+		}
+		CheckTest(t, "parse.Scanf", TTest{Name: test.name, Expect: test.expect}, got)
+	}
+	if err := Scanf("99999999999999999999", "%d", &x); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("expected a range error, got %v", err)
+	}
+}
+
+func TestMustCompilePattern(t *testing.T) {
+	p := MustCompilePattern("Button %c: X+%d, Y+%d")
+	var c rune
+	var x, y int
+	if err := p.Scan("Button A: X+94, Y+34", &c, &x, &y); err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "parse.Pattern", TTest{Name: "button", Expect: []int{'A', 94, 34}}, []int{int(c), x, y})
+	CheckTest(t, "parse.Pattern", TTest{Name: "string", Expect: "Button %c: X+%d, Y+%d"}, p.String())
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(error).Error(), "unknown verb") {
+			t.Errorf("expected a panic for a bad pattern, got %v", r)
+		}
+	}()
+	MustCompilePattern("%z")
+}
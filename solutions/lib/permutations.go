@@ -1,6 +1,7 @@
 package eulerlib
 
 import (
+	"context"
 	"math/big"
 	"slices"
 	"strings"
@@ -47,11 +48,24 @@ func (m *TPerms) Init(arr []int) {
 
 // GetPerms returns all permutations of the base array in different orders.
 func (m *TPerms) GetPerms() [][]int {
+	allPerms, _ := m.GetPermsContext(context.Background())
+	return allPerms
+}
+
+// GetPermsContext is GetPerms but gives up with ctx's error once ctx is done,
+// which matters for base arrays long enough to have billions of orderings.
+func (m *TPerms) GetPermsContext(ctx context.Context) ([][]int, error) {
 	allPerms := make([][]int, 0)
 	for p := make([]int, len(m.Arr)); p[0] < len(p); m.nextPerm(p) {
+		//checking every permutation would dominate the cost for short arrays
+		if len(allPerms)%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
 		allPerms = append(allPerms, m.getPerm(m.Arr, p))
 	}
-	return allPerms
+	return allPerms, nil
 }
 
 // GetFingerprint returns a canonical sorted-string fingerprint for the input
@@ -1,6 +1,8 @@
 package eulerlib
 
 import (
+	"context"
+	"errors"
 	"math/big"
 	"strings"
 	"testing"
@@ -522,3 +524,20 @@ func TestGetUniquePerms(t *testing.T) {
 		CheckTest(t, "permutations.GetUniquePerms", test, p.GetUniquePerms(false))
 	}
 }
+
+func TestGetPermsContext(t *testing.T) {
+	p := &TPerms{}
+	p.Init([]int{1, 2, 3})
+	perms, err := p.GetPermsContext(context.Background())
+	CheckTest(t, "permutations.GetPermsContext", TTest{Name: "background", Expect: p.GetPerms()}, perms)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.Init([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	if _, err := p.GetPermsContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
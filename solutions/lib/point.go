@@ -0,0 +1,203 @@
+package eulerlib
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Point is a position or offset on a grid, with y increasing downwards as in
+// puzzle input. It is a comparable value, so it can be a map key.
+type Point struct {
+	X int
+	Y int
+}
+
+// Offsets to the 4 and 8 neighbours of a point, clockwise from up.
+var (
+	neighbours4 = [4]Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+	neighbours8 = [8]Point{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+	// hexNeighbours are the 6 neighbours in axial coordinates, clockwise
+	// from east.
+	hexNeighbours = [6]Point{{1, 0}, {0, 1}, {-1, 1}, {-1, 0}, {0, -1}, {1, -1}}
+)
+
+// ParsePoint reads a point written "x,y", with optional spaces after the
+// comma.
+func ParsePoint(s string) (Point, error) {
+	ints, err := ParseInts(s, ",")
+	if err != nil {
+		return Point{}, err
+	}
+	if len(ints) != 2 {
+		return Point{}, &ParseError{Text: s, Msg: fmt.Sprintf("expected x,y, got %d values", len(ints))}
+	}
+	return Point{ints[0], ints[1]}, nil
+}
+
+// PointOf converts an [x, y] pair, as used by GetAdjacentList, to a Point.
+func PointOf(xy []int) Point {
+	return Point{xy[0], xy[1]}
+}
+
+// String formats the point as "x,y".
+func (m Point) String() string {
+	return fmt.Sprintf("%d,%d", m.X, m.Y)
+}
+
+// Add returns m + o.
+func (m Point) Add(o Point) Point {
+	return Point{m.X + o.X, m.Y + o.Y}
+}
+
+// Sub returns m - o, the offset from o to m.
+func (m Point) Sub(o Point) Point {
+	return Point{m.X - o.X, m.Y - o.Y}
+}
+
+// Scale returns m with both coordinates multiplied by k.
+func (m Point) Scale(k int) Point {
+	return Point{m.X * k, m.Y * k}
+}
+
+// Neg returns -m.
+func (m Point) Neg() Point {
+	return Point{-m.X, -m.Y}
+}
+
+// Move returns the point one step from m in direction d.
+func (m Point) Move(d TDirection) Point {
+	return m.Add(d.Point())
+}
+
+// Manhattan returns the taxicab distance from m to o, the number of
+// orthogonal steps between them.
+func (m Point) Manhattan(o Point) int {
+	return IntAbs(m.X-o.X) + IntAbs(m.Y-o.Y)
+}
+
+// Chebyshev returns the chessboard distance from m to o, the number of
+// steps between them when diagonal steps are allowed.
+func (m Point) Chebyshev(o Point) int {
+	return max(IntAbs(m.X-o.X), IntAbs(m.Y-o.Y))
+}
+
+// HexDistance returns the number of steps from m to o on a hex grid in
+// axial coordinates, as HexNeighbours walks.
+func (m Point) HexDistance(o Point) int {
+	d := m.Sub(o)
+	return (IntAbs(d.X) + IntAbs(d.Y) + IntAbs(d.X+d.Y)) / 2
+}
+
+// RotateRight turns m as an offset 90 degrees clockwise about the origin, as
+// seen with y increasing downwards: up becomes right.
+func (m Point) RotateRight() Point {
+	return Point{-m.Y, m.X}
+}
+
+// RotateLeft turns m as an offset 90 degrees anticlockwise about the origin.
+func (m Point) RotateLeft() Point {
+	return Point{m.Y, -m.X}
+}
+
+// Rotate turns m clockwise about the origin by quarterTurns quarter turns,
+// or anticlockwise if it is negative.
+func (m Point) Rotate(quarterTurns int) Point {
+	switch ((quarterTurns % 4) + 4) % 4 {
+	case 1:
+		return m.RotateRight()
+	case 2:
+		return m.Neg()
+	case 3:
+		return m.RotateLeft()
+	}
+	return m
+}
+
+// RotateAround turns m clockwise about centre by quarterTurns quarter turns.
+func (m Point) RotateAround(centre Point, quarterTurns int) Point {
+	return m.Sub(centre).Rotate(quarterTurns).Add(centre)
+}
+
+// Neighbours4 iterates over the 4 orthogonal neighbours of m, clockwise
+// from up.
+func (m Point) Neighbours4() iter.Seq[Point] {
+	return m.offsets(neighbours4[:], nil)
+}
+
+// Neighbours8 iterates over the 8 neighbours of m including diagonals,
+// clockwise from up.
+func (m Point) Neighbours8() iter.Seq[Point] {
+	return m.offsets(neighbours8[:], nil)
+}
+
+// HexNeighbours iterates over the 6 neighbours of m on a hex grid in axial
+// coordinates, where X runs east and Y south-east, clockwise from east.
+func (m Point) HexNeighbours() iter.Seq[Point] {
+	return m.offsets(hexNeighbours[:], nil)
+}
+
+// Neighbours4In is Neighbours4 limited to the points inside b, such as a
+// grid's bounds.
+func (m Point) Neighbours4In(b Bounds) iter.Seq[Point] {
+	return m.offsets(neighbours4[:], b)
+}
+
+// Neighbours8In is Neighbours8 limited to the points inside b.
+func (m Point) Neighbours8In(b Bounds) iter.Seq[Point] {
+	return m.offsets(neighbours8[:], b)
+}
+
+// Within iterates over the points other than m at a Manhattan distance of at
+// most r from it, nearest first, limited to those inside b. A nil b puts no
+// limit on them.
+func (m Point) Within(r int, b Bounds) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for d := 1; d <= r; d++ {
+			//walk the diamond of points at distance d, starting above m
+			p := Point{m.X, m.Y - d}
+			for _, step := range [4]Point{{1, 1}, {-1, 1}, {-1, -1}, {1, -1}} {
+				for range d {
+					if (b == nil || b.Contains(p)) && !yield(p) {
+						return
+					}
+					p = p.Add(step)
+				}
+			}
+		}
+	}
+}
+
+// offsets iterates over m plus each offset that lands inside b, or all of
+// them if b is nil.
+func (m Point) offsets(offsets []Point, b Bounds) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, o := range offsets {
+			p := m.Add(o)
+			if (b == nil || b.Contains(p)) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// Bounds is a region of the plane, such as the cells of a grid.
+type Bounds interface {
+	Contains(p Point) bool
+}
+
+// Rect is the rectangle of points from Min to Max inclusive.
+type Rect struct {
+	Min Point
+	Max Point
+}
+
+// Contains reports whether p lies inside the rectangle.
+func (m Rect) Contains(p Point) bool {
+	return p.X >= m.Min.X && p.X <= m.Max.X && p.Y >= m.Min.Y && p.Y <= m.Max.Y
+}
+
+// Contains reports whether p lies inside a grid of this size with its top
+// left cell at 0,0.
+func (m GridSize) Contains(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < m.Width && p.Y < m.Height
+}
@@ -0,0 +1,80 @@
+package eulerlib
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPointArithmetic(t *testing.T) {
+	p, q := Point{3, -2}, Point{-1, 4}
+	CheckTest(t, "Point.Add", TTest{Name: "add", Expect: Point{2, 2}}, p.Add(q))
+	CheckTest(t, "Point.Sub", TTest{Name: "sub", Expect: Point{4, -6}}, p.Sub(q))
+	CheckTest(t, "Point.Scale", TTest{Name: "scale", Expect: Point{9, -6}}, p.Scale(3))
+	CheckTest(t, "Point.Neg", TTest{Name: "neg", Expect: Point{-3, 2}}, p.Neg())
+	CheckTest(t, "Point.Move", TTest{Name: "left", Expect: Point{2, -2}}, p.Move(LeftDirection))
+	CheckTest(t, "Point.String", TTest{Name: "string", Expect: "3,-2"}, p.String())
+
+	CheckTest(t, "Point.Manhattan", TTest{Name: "manhattan", Expect: 10}, p.Manhattan(q))
+	CheckTest(t, "Point.Chebyshev", TTest{Name: "chebyshev", Expect: 6}, p.Chebyshev(q))
+	CheckTest(t, "Point.HexDistance", TTest{Name: "same row", Expect: 3}, Point{0, 0}.HexDistance(Point{3, 0}))
+	CheckTest(t, "Point.HexDistance", TTest{Name: "diagonal", Expect: 2}, Point{0, 0}.HexDistance(Point{1, -2}))
+	CheckTest(t, "Point.HexDistance", TTest{Name: "mixed", Expect: 3}, Point{0, 0}.HexDistance(Point{-1, 3}))
+}
+
+func TestPointRotate(t *testing.T) {
+	up := Point{0, -1}
+	CheckTest(t, "Point.RotateRight", TTest{Name: "up", Expect: RightDirection.Point()}, up.RotateRight())
+	CheckTest(t, "Point.RotateLeft", TTest{Name: "up", Expect: LeftDirection.Point()}, up.RotateLeft())
+	p := Point{2, 1}
+	for turns := -5; turns <= 5; turns++ {
+		want := p
+		for range (turns%4 + 4) % 4 {
+			want = want.RotateRight()
+		}
+		CheckTest(t, "Point.Rotate", TTest{Name: IntToStr(turns), Expect: want}, p.Rotate(turns))
+	}
+	CheckTest(t, "Point.RotateAround", TTest{Name: "centre", Expect: Point{4, 5}}, Point{5, 4}.RotateAround(Point{4, 4}, 1))
+}
+
+func TestPointNeighbours(t *testing.T) {
+	p := Point{0, 0}
+	CheckTest(t, "Point.Neighbours4", TTest{Name: "all", Expect: []Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}}, slices.Collect(p.Neighbours4()))
+	CheckTest(t, "Point.Neighbours8", TTest{Name: "count", Expect: 8}, len(slices.Collect(p.Neighbours8())))
+	for n := range p.HexNeighbours() {
+		CheckTest(t, "Point.HexNeighbours", TTest{Name: n.String(), Expect: 1}, p.HexDistance(n))
+	}
+
+	size := GridSize{Width: 3, Height: 2}
+	CheckTest(t, "Point.Neighbours4In", TTest{Name: "corner", Expect: []Point{{1, 0}, {0, 1}}}, slices.Collect(p.Neighbours4In(size)))
+	CheckTest(t, "Point.Neighbours8In", TTest{Name: "corner", Expect: []Point{{1, 0}, {1, 1}, {0, 1}}}, slices.Collect(p.Neighbours8In(size)))
+	g := MustParseRuneGrid([]string{"...", "..."})
+	CheckTest(t, "Point.Neighbours8In", TTest{Name: "grid", Expect: 5}, len(slices.Collect(Point{1, 1}.Neighbours8In(g))))
+}
+
+func TestPointWithin(t *testing.T) {
+	p := Point{5, 5}
+	within := slices.Collect(p.Within(2, nil))
+	CheckTest(t, "Point.Within", TTest{Name: "count", Expect: 12}, len(within))
+	CheckTest(t, "Point.Within", TTest{Name: "nearest first", Expect: []Point{{5, 4}, {6, 5}, {5, 6}, {4, 5}}}, within[:4])
+	for _, q := range within {
+		if d := p.Manhattan(q); d < 1 || d > 2 {
+			t.Errorf("Within(2) gave %v at distance %d", q, d)
+		}
+	}
+	bounded := slices.Collect(Point{0, 0}.Within(2, Rect{Min: Point{0, 0}, Max: Point{9, 9}}))
+	CheckTest(t, "Point.Within", TTest{Name: "bounded", Expect: 5}, len(bounded))
+}
+
+func TestParsePoint(t *testing.T) {
+	p, err := ParsePoint("7, -3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "ParsePoint", TTest{Name: "point", Expect: Point{7, -3}}, p)
+	_, err = ParsePoint("1,2,3")
+	CheckTest(t, "ParsePoint", TTest{Name: "three values", Expect: `expected x,y, got 3 values in "1,2,3"`}, err.Error())
+	_, err = ParsePoint("1,x")
+	if err == nil {
+		t.Error("expected an error for 1,x")
+	}
+}
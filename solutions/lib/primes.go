@@ -1,6 +1,7 @@
 package eulerlib
 
 import (
+	"context"
 	"slices"
 )
 
@@ -130,9 +131,19 @@ func (m *CompatiblePrimes) IsPrimePairSet(ps []int) bool {
 // is pairwise compatible with the others (all concatenations are prime) and
 // returns the number of such sets discovered.
 func (m *CompatiblePrimes) GenerateCompatible() int {
+	n, _ := m.GenerateCompatibleContext(context.Background())
+	return n
+}
+
+// GenerateCompatibleContext is GenerateCompatible but stops with ctx's error,
+// leaving the compatible sets ungenerated, once ctx is done.
+func (m *CompatiblePrimes) GenerateCompatibleContext(ctx context.Context) (int, error) {
 	primes := m.primeCache.GetNPrimes(m.numPrimes)
 	compatibleMap := map[int][]int{}
 	for i := 0; i < len(primes); i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		for j := i + 1; j < len(primes); j++ {
 			if primes[i] > 2 && primes[j] > 2 {
 				if m.IsPrimePairSet([]int{primes[i], primes[j]}) {
@@ -164,13 +175,19 @@ func (m *CompatiblePrimes) GenerateCompatible() int {
 			}
 		}
 	}
-	return len(m.compatibleArr)
+	return len(m.compatibleArr), nil
 }
 
 // Search scans the generated compatible prime sets for all unique
 // combinations of length comboLength where every pair of primes remains
 // compatible, returning each matching combination once.
 func (m *CompatiblePrimes) Search() [][]int {
+	matches, _ := m.SearchContext(context.Background())
+	return matches
+}
+
+// SearchContext is Search but stops with ctx's error once ctx is done.
+func (m *CompatiblePrimes) SearchContext(ctx context.Context) ([][]int, error) {
 	cache := map[string]bool{}
 	cacheHits := 0
 	cacheMisses := 0
@@ -178,6 +195,9 @@ func (m *CompatiblePrimes) Search() [][]int {
 	matches := [][]int{}
 	i := 0
 	for _, v := range m.compatibleArr {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		//fmt.Println("checking", len(v), comboLength)
 		if len(v) >= m.comboLength {
 			combos := m.perms.GetCombinations(v, m.comboLength)
@@ -219,5 +239,5 @@ func (m *CompatiblePrimes) Search() [][]int {
 		i++
 	}
 	//fmt.Println("max combos", maxCombos)
-	return matches
+	return matches, nil
 }
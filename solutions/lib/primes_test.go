@@ -1,6 +1,8 @@
 package eulerlib
 
 import (
+	"context"
+	"errors"
 	"testing"
 )
 
@@ -212,3 +214,17 @@ func TestCompatiblePrimesSearch(t *testing.T) {
 		t.Fatalf("CompatiblePrimes.Search returned no combinations, got %v", results)
 	}
 }
+
+func TestCompatiblePrimesContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cp := CompatiblePrimes{}
+	cp.Init(100, 4, 1000)
+	if _, err := cp.GenerateCompatibleContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected GenerateCompatibleContext to return context.Canceled, got %v", err)
+	}
+	cp.compatibleArr = [][]int{{3, 7}}
+	if _, err := cp.SearchContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected SearchContext to return context.Canceled, got %v", err)
+	}
+}
@@ -1,11 +1,17 @@
 package eulerlib
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
 
 // Problem defines the interface implemented by each Advent of Code day so
 // that common test helpers can verify both full and short solutions.
 type Problem interface {
 	GetProblemName() string
+	//an empty answer means it is not known yet; the answers manifest takes precedence
 	GetAnswer() string
 	GenerateAnswer() string
 	//if the short functions are implemented, they will be used instead of the full answer.
@@ -16,50 +22,116 @@ type Problem interface {
 	GetShortAnswer() string
 }
 
-// testLongProblem runs the standard answer path for a Problem and verifies the
-// generated answer matches the known correct answer.
-func testLongProblem(problem Problem, t *testing.T) {
-	//log.Println("long answer")
-	test := TTest{Name: "Solution", Input: nil, Expect: problem.GetAnswer()}
-	CheckTest(
-		t,
-		problem.GetProblemName(),
-		test,
-		problem.GenerateAnswer(),
-	)
+// testAnswer generates the full answer for a Problem, or the short answer
+// when short is true, and verifies it against the expected answer from the
+// answers manifest or GetAnswer/GetShortAnswer. It reports false, without
+// generating anything, when the correct answer is not known.
+func testAnswer(ctx context.Context, problem Problem, t *testing.T, search InputSearch, short bool) bool {
+	filename := DefaultInput
+	if short {
+		filename = DefaultShortInput
+	}
+	expect, err := expect(problem, search, filename, short)
+	if err != nil {
+		t.Errorf("Testing %s; reading answers manifest failed: %v", problem.GetProblemName(), err)
+	}
+	if !expect.Known() {
+		return false
+	}
+	test := TTest{Name: "Solution", Input: nil, Expect: expect.Answer}
+	answer, err := solveContext(ctx, problem, short)
+	if err == nil && expect.IsWrong(answer) {
+		t.Errorf("Testing %s; %s, output %v was already submitted and rejected", problem.GetProblemName(), test.Name, answer)
+		return true
+	}
+	checkAnswer(t, problem.GetProblemName(), test, answer, err)
+	return true
+}
+
+// generateAnswer calls generate, converting a panic into an error so that
+// problems such as a missing input file are reported as a test failure with
+// their message rather than aborting the test binary.
+func generateAnswer(generate func() (Answer, error)) (answer Answer, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return generate()
 }
 
-// testShortProblem runs the short answer path for a Problem and verifies the
-// generated short answer matches the expected sample answer.
-func testShortProblem(problem Problem, t *testing.T) {
-	//log.Println("short answer")
-	test := TTest{Name: "Solution", Input: nil, Expect: problem.GetShortAnswer()}
-	CheckTest(
-		t,
-		problem.GetProblemName(),
-		test,
-		problem.GenerateShortAnswer(),
-	)
+// checkAnswer reports whether a generated Answer equals the expected Answer
+// held in test.Expect, treating a generation error as a failure.
+func checkAnswer(t *testing.T, context string, test TTest, answer Answer, err error) {
+	if err != nil {
+		t.Errorf("Testing %s; %s, generating answer failed: %v", context, test.Name, err)
+		return
+	}
+	if !test.Expect.(Answer).Equal(answer) {
+		ReportError(t, context, test, answer)
+		return
+	}
+	ReportSuccess(context, test)
 }
 
-// hasShortAnswer reports whether a Problem implements a usable short answer,
-// safely handling any panics from GetShortAnswer.
-func hasShortAnswer(problem Problem) (has bool) {
+// shortAnswer returns a Problem's GetShortAnswer, or "" if it panics.
+func shortAnswer(problem Problem) (answer string) {
 	defer func() {
 		if r := recover(); r != nil {
-			has = false
+			answer = ""
 		}
 	}()
-	return problem.GetShortAnswer() != ""
+	return problem.GetShortAnswer()
+}
+
+// hasShortAnswer reports whether a Problem implements a usable short answer,
+// safely handling any panics from GetShortAnswer.
+func hasShortAnswer(problem Problem) bool {
+	return shortAnswer(problem) != ""
+}
+
+// useShort reports whether TestProblem should check the short answer rather
+// than the full one: when the problem gives a short answer, or when the
+// answers manifest has a trusted answer for its sample input, such as one
+// recorded by aoc examples.
+func useShort(problem Problem, search InputSearch) bool {
+	if hasShortAnswer(problem) {
+		return true
+	}
+	expect, err := expect(problem, search, DefaultShortInput, true)
+	return err == nil && expect.Known()
 }
 
 // TestProblem chooses between the short and full solution paths for a Problem,
-// preferring the short answer when available and falling back to the long
-// answer otherwise.
+// preferring the short answer when GetShortAnswer or the answers manifest
+// knows it and falling back to the long answer otherwise. The answer must be
+// generated within TestTimeout. Any examples, listed by an ExampleProblem or
+// in the examples folder next to the calling test, are then run as subtests. A problem whose correct answer is
+// not known, being marked unknown in the answers manifest or having an empty
+// GetAnswer, is reported as skipped once its examples have run. Solutions
+// run in strict mode, so a bad integer given to the legacy helpers of a
+// Conv from their Logging or context fails the test, unless AOC_STRICT is
+// set to 0.
 func TestProblem(problem Problem, t *testing.T) {
-	if hasShortAnswer(problem) {
-		testShortProblem(problem, t)
-	} else {
-		testLongProblem(problem, t)
+	testProblem(problem, t, TestTimeout(), callerInputSearch(1))
+}
+
+// TestProblemWithTimeout is TestProblem with an explicit deadline, for
+// solutions that legitimately need longer (or should finish sooner) than
+// the default. A timeout of zero or less disables the deadline.
+func TestProblemWithTimeout(problem Problem, t *testing.T, timeout time.Duration) {
+	testProblem(problem, t, timeout, callerInputSearch(1))
+}
+
+// testProblem implements TestProblem for the solution whose inputs and
+// examples are found through search.
+func testProblem(problem Problem, t *testing.T, timeout time.Duration, search InputSearch) {
+	base := WithStrict(context.Background(), TestStrict())
+	ctx, cancel := WithTimeout(base, timeout)
+	defer cancel()
+	known := testAnswer(ctx, problem, t, search, useShort(problem, search))
+	testExamples(base, problem, t, search.Dir, timeout)
+	if !known {
+		t.Skipf("%s: answer not known yet; record it with aoc answers verify", problem.GetProblemName())
 	}
 }
@@ -65,3 +65,30 @@ func TestTestProblemFallsBackToLongOnPanic(t *testing.T) {
 	problem := &TPanickingShortProblem{}
 	TestProblem(problem, t)
 }
+
+// Problem whose answer records whether it was generated in strict mode.
+type TStrictProblem struct {
+	Problem
+	Logging
+	strict bool
+}
+
+func (m *TStrictProblem) GetProblemName() string { return "Strict Problem" }
+
+func (m *TStrictProblem) GetAnswer() string { return "7" }
+
+func (m *TStrictProblem) GenerateAnswer() string {
+	m.strict = m.Conv().Strict
+	return IntToStr(m.Conv().StrToInt("7"))
+}
+
+func TestTestProblemIsStrict(t *testing.T) {
+	t.Setenv(StrictEnv, "")
+	problem := &TStrictProblem{}
+	TestProblem(problem, t)
+	CheckTest(t, "problem.TestProblem", TTest{Name: "strict while solving", Expect: true}, problem.strict)
+
+	t.Setenv(StrictEnv, "0")
+	TestProblem(problem, t)
+	CheckTest(t, "problem.TestProblem", TTest{Name: "AOC_STRICT=0", Expect: false}, problem.strict)
+}
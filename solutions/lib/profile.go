@@ -0,0 +1,195 @@
+package eulerlib
+
+import (
+	"cmp"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"slices"
+
+	"github.com/google/pprof/profile"
+)
+
+// ProfileOptions names the directories that CPU, memory and execution trace
+// profiles are written to. An empty directory disables that profile.
+type ProfileOptions struct {
+	CPUDir   string
+	MemDir   string
+	TraceDir string
+}
+
+// Enabled reports whether any profile is requested.
+func (m ProfileOptions) Enabled() bool {
+	return m.CPUDir != "" || m.MemDir != "" || m.TraceDir != ""
+}
+
+// ProfileFiles lists the files written by Profile; unrequested profiles are
+// left empty.
+type ProfileFiles struct {
+	CPU   string
+	Mem   string
+	Trace string
+}
+
+// ProfileName returns the base name for a problem's profile files, such as
+// "day10-2", or "day10-2-short" for the sample input.
+func ProfileName(info ProblemInfo, short bool) string {
+	if short {
+		return info.FolderName() + "-short"
+	}
+	return info.FolderName()
+}
+
+// Profile calls f while capturing the profiles selected by opts, writing
+// them as <name>.cpu.pprof, <name>.mem.pprof and <name>.trace. The memory
+// profile is the heap profile taken after f returns, so its allocation
+// figures include everything allocated earlier in the process too. f is
+// called even if a profile cannot be started, and the first error is
+// returned.
+func Profile(opts ProfileOptions, name string, f func()) (files ProfileFiles, err error) {
+	create := func(dir, ext string) (*os.File, error) {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		return os.Create(filepath.Join(dir, name+ext))
+	}
+	keep := func(e error) {
+		if err == nil {
+			err = e
+		}
+	}
+
+	if opts.CPUDir != "" {
+		if out, e := create(opts.CPUDir, ".cpu.pprof"); e != nil {
+			keep(e)
+		} else if e := pprof.StartCPUProfile(out); e != nil {
+			keep(e)
+			out.Close()
+		} else {
+			files.CPU = out.Name()
+			defer func() {
+				pprof.StopCPUProfile()
+				keep(out.Close())
+			}()
+		}
+	}
+	if opts.TraceDir != "" {
+		if out, e := create(opts.TraceDir, ".trace"); e != nil {
+			keep(e)
+		} else if e := trace.Start(out); e != nil {
+			keep(e)
+			out.Close()
+		} else {
+			files.Trace = out.Name()
+			defer func() {
+				trace.Stop()
+				keep(out.Close())
+			}()
+		}
+	}
+
+	f()
+
+	if opts.MemDir != "" {
+		out, e := create(opts.MemDir, ".mem.pprof")
+		if e != nil {
+			keep(e)
+			return files, err
+		}
+		runtime.GC()
+		keep(pprof.Lookup("heap").WriteTo(out, 0))
+		keep(out.Close())
+		files.Mem = out.Name()
+	}
+	return files, err
+}
+
+// ProfileEntry is one function's share of a profile's samples. Flat counts
+// samples in the function itself and Cum samples with it anywhere on the
+// stack.
+type ProfileEntry struct {
+	Function string
+	Flat     int64
+	Cum      int64
+}
+
+// ProfileTop is the summary printed by "go tool pprof -top": the functions
+// with the most flat samples.
+type ProfileTop struct {
+	// Unit is the unit of the summarised sample value, such as
+	// "nanoseconds" for a CPU profile.
+	Unit    string
+	Total   int64
+	Entries []ProfileEntry
+}
+
+// ReadProfileTop reads a pprof profile and returns its n functions
+// with the highest flat value, using the last sample value (CPU time for a
+// CPU profile, in-use bytes for a heap profile).
+func ReadProfileTop(filename string, n int) (ProfileTop, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return ProfileTop{}, err
+	}
+	defer f.Close()
+	return readProfileTop(f, n)
+}
+
+// readProfileTop implements ReadProfileTop for a reader.
+func readProfileTop(r io.Reader, n int) (ProfileTop, error) {
+	p, err := profile.Parse(r)
+	if err != nil {
+		return ProfileTop{}, err
+	}
+
+	top := ProfileTop{}
+	if len(p.SampleType) > 0 {
+		top.Unit = p.SampleType[len(p.SampleType)-1].Unit
+	}
+	byName := map[string]*ProfileEntry{}
+	entry := func(name string) *ProfileEntry {
+		if e, ok := byName[name]; ok {
+			return e
+		}
+		e := &ProfileEntry{Function: name}
+		byName[name] = e
+		return e
+	}
+	for _, s := range p.Sample {
+		if len(s.Value) == 0 {
+			continue
+		}
+		v := s.Value[len(s.Value)-1]
+		top.Total += v
+		seen := map[string]bool{}
+		for i, loc := range s.Location {
+			for j, line := range loc.Line {
+				name := "?"
+				if line.Function != nil {
+					name = line.Function.Name
+				}
+				//the first line of the first location is the innermost frame
+				if i == 0 && j == 0 {
+					entry(name).Flat += v
+				}
+				if !seen[name] {
+					seen[name] = true
+					entry(name).Cum += v
+				}
+			}
+		}
+	}
+	for _, e := range byName {
+		top.Entries = append(top.Entries, *e)
+	}
+	slices.SortFunc(top.Entries, func(a, b ProfileEntry) int {
+		return cmp.Or(cmp.Compare(b.Flat, a.Flat), cmp.Compare(b.Cum, a.Cum), cmp.Compare(a.Function, b.Function))
+	})
+	if n > 0 && len(top.Entries) > n {
+		top.Entries = top.Entries[:n]
+	}
+	return top, nil
+}
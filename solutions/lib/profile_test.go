@@ -0,0 +1,85 @@
+package eulerlib
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"strings"
+	"testing"
+	"time"
+)
+
+// profileSpin burns CPU until d has passed so it shows up in a CPU profile.
+func profileSpin(d time.Duration) int {
+	n := 0
+	for start := time.Now(); time.Since(start) < d; {
+		for i := range 10000 {
+			n += i % 7
+		}
+	}
+	return n
+}
+
+func TestProfileName(t *testing.T) {
+	info := ProblemInfo{Day: 10, Part: 2}
+	CheckTest(t, "profile.ProfileName", TTest{Name: "full", Expect: "day10-2"}, ProfileName(info, false))
+	CheckTest(t, "profile.ProfileName", TTest{Name: "short", Expect: "day10-2-short"}, ProfileName(info, true))
+}
+
+func TestProfile(t *testing.T) {
+	dir := t.TempDir()
+	opts := ProfileOptions{CPUDir: filepath.Join(dir, "cpu"), MemDir: dir, TraceDir: dir}
+	files, err := Profile(opts, "day1-1", func() { profileSpin(200 * time.Millisecond) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "profile.Profile", TTest{Name: "cpu file", Expect: filepath.Join(dir, "cpu", "day1-1.cpu.pprof")}, files.CPU)
+	for _, path := range []string{files.CPU, files.Mem, files.Trace} {
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("expected a non-empty profile at %s (%v)", path, err)
+		}
+	}
+
+	top, err := ReadProfileTop(files.CPU, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "profile.ReadProfileTop", TTest{Name: "unit", Expect: "nanoseconds"}, top.Unit)
+	if top.Total <= 0 || len(top.Entries) == 0 || len(top.Entries) > 5 {
+		t.Fatalf("expected up to 5 entries with a positive total, got %+v", top)
+	}
+	found := false
+	for _, e := range top.Entries {
+		if strings.HasSuffix(e.Function, "profileSpin") {
+			found = e.Cum > 0 && e.Flat <= e.Cum
+		}
+	}
+	if !found {
+		t.Errorf("expected profileSpin in the top entries, got %+v", top.Entries)
+	}
+
+	_, err = Profile(ProfileOptions{CPUDir: filepath.Join(files.CPU, "not-a-dir")}, "x", func() {})
+	if err == nil {
+		t.Error("expected an error when the profile directory cannot be created")
+	}
+	if !(ProfileOptions{TraceDir: "t"}).Enabled() || (ProfileOptions{}).Enabled() {
+		t.Error("unexpected ProfileOptions.Enabled")
+	}
+}
+
+func TestReadProfileTopHeap(t *testing.T) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("heap").WriteTo(&buf, 0); err != nil {
+		t.Fatal(err)
+	}
+	top, err := readProfileTop(&buf, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "profile.ReadProfileTop", TTest{Name: "heap unit", Expect: "bytes"}, top.Unit)
+
+	if _, err := readProfileTop(strings.NewReader("not gzip"), 3); err == nil {
+		t.Error("expected an error for a file that is not a profile")
+	}
+}
@@ -0,0 +1,337 @@
+package eulerlib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultProgressInterval is how often a Progress is rendered unless told
+// otherwise.
+const DefaultProgressInterval = 500 * time.Millisecond
+
+// Task counts the progress of one piece of work, optionally out of a known
+// total, and may have nested sub-tasks. Add and Increment are a single atomic
+// operation, so searches can update a Task from many goroutines in their hot
+// loops.
+type Task struct {
+	name  string
+	count atomic.Int64
+	total atomic.Int64
+	done  atomic.Bool
+	start time.Time
+
+	mu sync.Mutex
+	// children holds the unfinished sub-tasks, and finished ones until the
+	// next Task call or render removes them.
+	children []*Task
+}
+
+// NewTask returns a Task started now that is not part of any Progress, so it
+// counts but is never shown. Tasks that are shown are started with
+// Progress.Task or Task.Task.
+func NewTask(name string, total int64) *Task {
+	t := &Task{name: name, start: time.Now()}
+	t.total.Store(total)
+	return t
+}
+
+// Task starts a sub-task of t. A total of zero or less means the amount of
+// work is not known, and the sub-task is shown as a plain counter.
+func (m *Task) Task(name string, total int64) *Task {
+	child := NewTask(name, total)
+	m.mu.Lock()
+	m.prune()
+	m.children = append(m.children, child)
+	m.mu.Unlock()
+	return child
+}
+
+// prune drops finished sub-tasks, which are never shown again, so that a
+// long run starting many short tasks does not keep them all. m.mu must be
+// held.
+func (m *Task) prune() {
+	m.children = slices.DeleteFunc(m.children, func(child *Task) bool {
+		return child.done.Load()
+	})
+}
+
+// Add records n more units of work done.
+func (m *Task) Add(n int64) {
+	m.count.Add(n)
+}
+
+// Increment records one more unit of work done.
+func (m *Task) Increment() {
+	m.count.Add(1)
+}
+
+// SetTotal sets the amount of work once it is known.
+func (m *Task) SetTotal(total int64) {
+	m.total.Store(total)
+}
+
+// Done marks the task finished, which hides it and its sub-tasks.
+func (m *Task) Done() {
+	m.done.Store(true)
+}
+
+// Count returns the work done so far.
+func (m *Task) Count() int64 {
+	return m.count.Load()
+}
+
+// TaskStatus is a snapshot of one Task for rendering.
+type TaskStatus struct {
+	Name string
+	// Path is the task's name prefixed with its parents', such as
+	// "day10-2/machines".
+	Path string
+	// Depth is the nesting level, zero for a top-level task.
+	Depth   int
+	Count   int64
+	Total   int64
+	Elapsed time.Duration
+	// Rate is the work done per second since the task started.
+	Rate float64
+	// ETA estimates the time left from Rate; it is zero when there is no
+	// total or no work done yet.
+	ETA time.Duration
+}
+
+// Fraction returns the proportion of the total done, or -1 without a total.
+func (m TaskStatus) Fraction() float64 {
+	if m.Total <= 0 {
+		return -1
+	}
+	return min(float64(m.Count)/float64(m.Total), 1)
+}
+
+// idle reports whether the task has neither a total nor any work counted,
+// as for a task that only groups its sub-tasks.
+func (m TaskStatus) idle() bool {
+	return m.Total <= 0 && m.Count == 0
+}
+
+// status returns the task's TaskStatus at now.
+func (m *Task) status(path string, depth int, now time.Time) TaskStatus {
+	s := TaskStatus{Name: m.name, Path: path, Depth: depth, Count: m.Count(), Total: m.total.Load(), Elapsed: now.Sub(m.start)}
+	if secs := s.Elapsed.Seconds(); secs > 0 {
+		s.Rate = float64(s.Count) / secs
+	}
+	if s.Total > 0 && s.Count > 0 && s.Count < s.Total {
+		s.ETA = time.Duration(float64(s.Total-s.Count) / s.Rate * float64(time.Second))
+	}
+	return s
+}
+
+// appendActive appends the statuses of the unfinished tasks below m, depth
+// first.
+func (m *Task) appendActive(statuses []TaskStatus, prefix string, depth int, now time.Time) []TaskStatus {
+	m.mu.Lock()
+	m.prune()
+	children := slices.Clone(m.children)
+	m.mu.Unlock()
+	for _, child := range children {
+		if child.done.Load() {
+			continue
+		}
+		path := prefix + child.name
+		statuses = append(statuses, child.status(path, depth, now))
+		statuses = child.appendActive(statuses, path+"/", depth+1, now)
+	}
+	return statuses
+}
+
+// ProgressRenderer displays the active tasks of a Progress. Render is called
+// from a single goroutine, with final set on the last call.
+type ProgressRenderer interface {
+	Render(tasks []TaskStatus, final bool)
+}
+
+// Progress is the root of a tree of Tasks, rendered periodically between
+// Start and Stop. A Progress without a renderer still counts, so code can
+// report progress unconditionally.
+type Progress struct {
+	root     *Task
+	renderer ProgressRenderer
+	every    time.Duration
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewProgress returns a Progress drawn by renderer every interval, or every
+// DefaultProgressInterval if interval is zero or less. A nil renderer draws
+// nothing.
+func NewProgress(renderer ProgressRenderer, interval time.Duration) *Progress {
+	if interval <= 0 {
+		interval = DefaultProgressInterval
+	}
+	return &Progress{root: NewTask("", 0), renderer: renderer, every: interval}
+}
+
+// Task starts a top-level task.
+func (m *Progress) Task(name string, total int64) *Task {
+	return m.root.Task(name, total)
+}
+
+// Snapshot returns the status of every unfinished task, depth first.
+func (m *Progress) Snapshot() []TaskStatus {
+	return m.root.appendActive(nil, "", 0, time.Now())
+}
+
+// Start begins rendering in the background until Stop is called.
+func (m *Progress) Start() {
+	if m.renderer == nil || m.stop != nil {
+		return
+	}
+	m.stop = make(chan struct{})
+	m.stopped = make(chan struct{})
+	go func() {
+		defer close(m.stopped)
+		ticker := time.NewTicker(m.every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stop:
+				m.renderer.Render(m.Snapshot(), true)
+				return
+			case <-ticker.C:
+				m.renderer.Render(m.Snapshot(), false)
+			}
+		}
+	}()
+}
+
+// Stop stops rendering after a final render.
+func (m *Progress) Stop() {
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	<-m.stopped
+	m.stop = nil
+}
+
+// progressKey is the context key for the Task attached by WithProgress.
+type progressKey struct{}
+
+// WithProgress returns a copy of ctx carrying t, under which a solution adds
+// its own tasks.
+func WithProgress(ctx context.Context, t *Task) context.Context {
+	return context.WithValue(ctx, progressKey{}, t)
+}
+
+// ProgressFrom returns the Task attached to ctx by WithProgress. Without one
+// it returns a detached Task that counts but is never shown, so solutions can
+// report progress whether or not anything is displaying it.
+func ProgressFrom(ctx context.Context) *Task {
+	if t, ok := ctx.Value(progressKey{}).(*Task); ok && t != nil {
+		return t
+	}
+	return NewTask("", 0)
+}
+
+// ProgressProblem is implemented by solutions that report progress. Like
+// LoggerProblem, TestProblem and the runner call SetProgress with the Task
+// from the run's context before solving. Embedding Logging implements it.
+type ProgressProblem interface {
+	SetProgress(*Task)
+}
+
+// TTYRenderer draws tasks as bars on a terminal, redrawing them in place.
+type TTYRenderer struct {
+	w     io.Writer
+	width int
+	lines int
+}
+
+// NewTTYRenderer returns a TTYRenderer writing to w with bars width
+// characters wide.
+func NewTTYRenderer(w io.Writer, width int) *TTYRenderer {
+	return &TTYRenderer{w: w, width: width}
+}
+
+// Render redraws the tasks over the previous render. The last render is
+// erased, leaving the terminal as it was.
+func (m *TTYRenderer) Render(tasks []TaskStatus, final bool) {
+	var b strings.Builder
+	if m.lines > 0 {
+		//back to the start of the first line drawn last time
+		fmt.Fprintf(&b, "\x1b[%dF", m.lines)
+	}
+	if !final {
+		for _, t := range tasks {
+			fmt.Fprintf(&b, "\x1b[2K%s\n", m.line(t))
+		}
+	}
+	//clear whatever is left of a longer previous render
+	b.WriteString("\x1b[J")
+	m.lines = len(tasks)
+	if final {
+		m.lines = 0
+	}
+	io.WriteString(m.w, b.String())
+}
+
+// line formats one task: a bar with a percentage and ETA when the total is
+// known, a counter when something has been counted, and otherwise just the
+// time taken so far.
+func (m *TTYRenderer) line(t TaskStatus) string {
+	indent := strings.Repeat("  ", t.Depth)
+	if t.idle() {
+		return fmt.Sprintf("%s%s %s", indent, t.Name, t.Elapsed.Round(time.Second))
+	}
+	rate := formatRate(t.Rate)
+	if f := t.Fraction(); f >= 0 {
+		filled := int(f * float64(m.width))
+		bar := strings.Repeat("#", filled) + strings.Repeat(".", m.width-filled)
+		return fmt.Sprintf("%s%s [%s] %d/%d %3.0f%% %s ETA %s", indent, t.Name, bar, t.Count, t.Total, 100*f, rate, t.ETA.Round(time.Second))
+	}
+	return fmt.Sprintf("%s%s %d %s %s", indent, t.Name, t.Count, rate, t.Elapsed.Round(time.Second))
+}
+
+// LogRenderer reports tasks as info records through a Debugger, for output
+// that is not a terminal.
+type LogRenderer struct {
+	log *Debugger
+}
+
+// NewLogRenderer returns a LogRenderer logging to d.
+func NewLogRenderer(d *Debugger) *LogRenderer {
+	return &LogRenderer{log: d}
+}
+
+// Render logs one record per task. Nothing is logged on the final render,
+// since finished tasks are not listed.
+func (m *LogRenderer) Render(tasks []TaskStatus, final bool) {
+	for _, t := range tasks {
+		if t.idle() {
+			//a task that only groups others says nothing its sub-tasks don't
+			continue
+		}
+		args := []any{"task", t.Path, "count", t.Count, "rate", formatRate(t.Rate), "elapsed", t.Elapsed.Round(time.Second)}
+		if t.Total > 0 {
+			args = append(args, "total", t.Total, "eta", t.ETA.Round(time.Second))
+		}
+		m.log.Info("progress", args...)
+	}
+}
+
+// formatRate formats a rate per second with a k, M or G suffix.
+func formatRate(rate float64) string {
+	switch {
+	case rate >= 1e9:
+		return fmt.Sprintf("%.1fG/s", rate/1e9)
+	case rate >= 1e6:
+		return fmt.Sprintf("%.1fM/s", rate/1e6)
+	case rate >= 1e3:
+		return fmt.Sprintf("%.1fk/s", rate/1e3)
+	}
+	return fmt.Sprintf("%.0f/s", rate)
+}
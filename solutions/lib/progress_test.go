@@ -0,0 +1,156 @@
+package eulerlib
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTaskConcurrentAdd(t *testing.T) {
+	p := NewProgress(nil, 0)
+	task := p.Task("search", 0)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				task.Increment()
+			}
+		}()
+	}
+	wg.Wait()
+	CheckTest(t, "progress.Task", TTest{Name: "count", Expect: int64(8000)}, task.Count())
+}
+
+func TestProgressSnapshot(t *testing.T) {
+	p := NewProgress(nil, 0)
+	day := p.Task("day10-2", 0)
+	machines := day.Task("machines", 4)
+	machines.Add(2)
+	finished := day.Task("parse", 1)
+	finished.Done()
+
+	statuses := p.Snapshot()
+	CheckTest(t, "progress.Snapshot", TTest{Name: "tasks", Expect: 2}, len(statuses))
+	CheckTest(t, "progress.Snapshot", TTest{Name: "path", Expect: "day10-2/machines"}, statuses[1].Path)
+	CheckTest(t, "progress.Snapshot", TTest{Name: "depth", Expect: 1}, statuses[1].Depth)
+	CheckTest(t, "progress.Snapshot", TTest{Name: "fraction", Expect: 0.5}, statuses[1].Fraction())
+	CheckTest(t, "progress.Snapshot", TTest{Name: "no total", Expect: -1.0}, statuses[0].Fraction())
+	if statuses[1].Rate <= 0 || statuses[1].ETA <= 0 {
+		t.Errorf("expected a rate and ETA, got %v and %v", statuses[1].Rate, statuses[1].ETA)
+	}
+
+	day.Done()
+	CheckTest(t, "progress.Snapshot", TTest{Name: "done", Expect: 0}, len(p.Snapshot()))
+}
+
+func TestTaskPrunesFinished(t *testing.T) {
+	p := NewProgress(nil, 0)
+	day := p.Task("day11-2", 0)
+	for range 100 {
+		day.Task("routes", 0).Done()
+	}
+	//each new task drops the finished ones before it
+	CheckTest(t, "progress.Task", TTest{Name: "pruned on Task", Expect: 1}, len(day.children))
+	running := day.Task("routes", 0)
+	day.Task("parse", 0).Done()
+	p.Snapshot()
+	CheckTest(t, "progress.Task", TTest{Name: "pruned on render", Expect: []*Task{running}}, day.children)
+}
+
+func TestProgressFrom(t *testing.T) {
+	detached := ProgressFrom(context.Background())
+	detached.Increment()
+	p := NewProgress(nil, 0)
+	task := p.Task("day1-1", 0)
+	if ProgressFrom(WithProgress(context.Background(), task)) != task {
+		t.Error("expected ProgressFrom to return the attached task")
+	}
+	CheckTest(t, "progress.ProgressFrom", TTest{Name: "detached", Expect: 1}, len(p.Snapshot()))
+}
+
+func TestTTYRenderer(t *testing.T) {
+	var bf bytes.Buffer
+	r := NewTTYRenderer(&bf, 10)
+	r.Render([]TaskStatus{
+		{Name: "machines", Count: 3, Total: 10, Rate: 1500, ETA: 5 * time.Second},
+		{Name: "states", Depth: 1, Count: 42, Rate: 42, Elapsed: time.Second},
+		{Name: "day10-2", Elapsed: 2 * time.Second},
+	}, false)
+	out := bf.String()
+	for _, want := range []string{"machines [###.......] 3/10  30% 1.5k/s ETA 5s\n", "\x1b[2K  states 42 42/s 1s\n", "\x1b[2Kday10-2 2s\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+
+	bf.Reset()
+	r.Render(nil, true)
+	CheckTest(t, "progress.TTYRenderer", TTest{Name: "final", Expect: "\x1b[3F\x1b[J"}, bf.String())
+}
+
+func TestLogRenderer(t *testing.T) {
+	var bf bytes.Buffer
+	d, _ := NewLogger(&bf, LogOptions{Level: slog.LevelInfo})
+	NewLogRenderer(d).Render([]TaskStatus{{Path: "day9-2"}, {Path: "day9-2/rectangles", Count: 5, Total: 10, Rate: 5, ETA: time.Second}}, false)
+	if !strings.Contains(bf.String(), "msg=progress task=day9-2/rectangles count=5 rate=5/s elapsed=0s total=10 eta=1s") {
+		t.Errorf("unexpected progress record %q", bf.String())
+	}
+	CheckTest(t, "progress.LogRenderer", TTest{Name: "records", Expect: 1}, strings.Count(bf.String(), "\n"))
+}
+
+// countingRenderer counts renders and records whether the last was final.
+type countingRenderer struct {
+	renders int
+	final   bool
+}
+
+func (m *countingRenderer) Render(tasks []TaskStatus, final bool) {
+	m.renders++
+	m.final = final
+}
+
+func TestProgressStartStop(t *testing.T) {
+	r := &countingRenderer{}
+	p := NewProgress(r, time.Millisecond)
+	p.Start()
+	time.Sleep(20 * time.Millisecond)
+	p.Stop()
+	if r.renders < 2 || !r.final {
+		t.Errorf("expected periodic renders ending with a final one, got %d (final %v)", r.renders, r.final)
+	}
+	//a Progress without a renderer never starts
+	NewProgress(nil, 0).Start()
+}
+
+func TestRunAllProgress(t *testing.T) {
+	p := NewProgress(nil, 0)
+	seen := make(chan int, 1)
+	problems := []ProblemInfo{{Year: DefaultYear, Day: 1, Part: 1, New: func() Problem {
+		return &TProgressProblem{seen: seen, progress: p}
+	}}}
+	RunAll(context.Background(), problems, RunOptions{Progress: p, LogOutput: io.Discard})
+	CheckTest(t, "runner.RunAllProgress", TTest{Name: "active while running", Expect: 2}, <-seen)
+	CheckTest(t, "runner.RunAllProgress", TTest{Name: "active after", Expect: 0}, len(p.Snapshot()))
+}
+
+// TProgressProblem reports progress under the task it is given and records
+// how many tasks were active while it ran.
+type TProgressProblem struct {
+	TTestProblem
+	Logging
+	seen     chan int
+	progress *Progress
+}
+
+func (m *TProgressProblem) GenerateAnswer() string {
+	m.Progress().Task("steps", 3).Add(3)
+	m.seen <- len(m.progress.Snapshot())
+	return "1234"
+}
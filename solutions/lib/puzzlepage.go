@@ -0,0 +1,153 @@
+package eulerlib
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	codeBlockRe = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	// emphasisedRe matches an emphasised inline code value, which is how a
+	// puzzle description marks the answer to an example.
+	emphasisedRe = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>|<em><code>(.*?)</code></em>`)
+	tagRe        = regexp.MustCompile(`<[^>]*>`)
+)
+
+// PuzzleExample is an example from a puzzle description: an input given in a
+// <pre><code> block and the emphasised answer the description gives for it.
+type PuzzleExample struct {
+	// Part is the part of the puzzle whose description the example is in.
+	Part  int
+	Input []string
+	// Answer is empty when no answer follows the example.
+	Answer Answer
+}
+
+// ParsePuzzleExamples returns the examples in a puzzle page, in page order.
+// Each <article> of the page describes one part. An example's answer is the
+// last emphasised code value after its block and before the next one. A part
+// that reuses an earlier example has no block of its own; it is returned with
+// a nil Input and the part's last emphasised answer, which PartExamples pairs
+// with the earlier input.
+func ParsePuzzleExamples(page string) []PuzzleExample {
+	articles := articleRe.FindAllStringSubmatch(page, -1)
+	if articles == nil {
+		articles = [][]string{{page, page}}
+	}
+	var examples []PuzzleExample
+	for i, article := range articles {
+		part, text := i+1, article[1]
+		blocks := codeBlockRe.FindAllStringSubmatchIndex(text, -1)
+		if len(blocks) == 0 {
+			if answer := lastEmphasised(text); !answer.IsEmpty() {
+				examples = append(examples, PuzzleExample{Part: part, Answer: answer})
+			}
+			continue
+		}
+		for j, block := range blocks {
+			end := len(text)
+			if j+1 < len(blocks) {
+				end = blocks[j+1][0]
+			}
+			examples = append(examples, PuzzleExample{
+				Part:   part,
+				Input:  SplitLines(pageText(text[block[2]:block[3]])),
+				Answer: lastEmphasised(text[block[1]:end]),
+			})
+		}
+	}
+	return examples
+}
+
+// PartExamples returns the examples for one part. A part whose description
+// only gives a new answer for an earlier example gets that example's input.
+func PartExamples(examples []PuzzleExample, part int) []PuzzleExample {
+	var selected []PuzzleExample
+	var earlier []string
+	for _, example := range examples {
+		if example.Part < part && earlier == nil {
+			earlier = example.Input
+		}
+		if example.Part != part {
+			continue
+		}
+		if example.Input == nil {
+			if earlier == nil {
+				continue
+			}
+			example.Input = earlier
+		}
+		selected = append(selected, example)
+	}
+	return selected
+}
+
+// lastEmphasised returns the last emphasised code value in a fragment of a
+// page, or the empty Answer if there is none.
+func lastEmphasised(fragment string) Answer {
+	matches := emphasisedRe.FindAllStringSubmatch(fragment, -1)
+	if matches == nil {
+		return Answer{}
+	}
+	last := matches[len(matches)-1]
+	return ParseAnswer(pageText(last[1] + last[2]))
+}
+
+// pageText returns the text of an HTML fragment without its markup.
+func pageText(fragment string) string {
+	return html.UnescapeString(tagRe.ReplaceAllString(fragment, ""))
+}
+
+// WriteExamples writes a part's examples into a solution directory. The
+// first becomes the sample input, dir/input-test.txt, and the rest become
+// examples/example-N.txt with their answers in example-N.answer, where N
+// counts from 2. It refuses to replace a file that has any content, such as
+// a sample input pasted in by hand, unless overwrite is set; the empty files
+// left by "aoc new" are replaced. It returns the files written.
+func WriteExamples(dir string, examples []PuzzleExample, overwrite bool) ([]string, error) {
+	if len(examples) == 0 {
+		return nil, ErrNoExample
+	}
+	files := map[string]string{
+		filepath.Join(dir, DefaultShortInput): strings.Join(examples[0].Input, "\n"),
+	}
+	for i, example := range examples[1:] {
+		base := filepath.Join(dir, ExamplesDir, fmt.Sprintf("example-%d", i+2))
+		files[base+".txt"] = strings.Join(example.Input, "\n")
+		if !example.Answer.IsEmpty() {
+			files[base+ExampleAnswerExt] = example.Answer.String()
+		}
+	}
+	if !overwrite {
+		for path := range files {
+			info, err := os.Stat(path)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+			if err == nil && info.Size() > 0 {
+				return nil, fmt.Errorf("%s already exists", path)
+			}
+		}
+	}
+	written := make([]string, 0, len(files))
+	for path := range files {
+		written = append(written, path)
+	}
+	slices.Sort(written)
+	for _, path := range written {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(files[path]), 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,85 @@
+package eulerlib
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// dialExample is the first example of testdata/puzzle-day1.html.
+var dialExample = []string{"L68", "L30", "R48", "L5", "R60", "L55", "L1", "L99", "R14", "L82"}
+
+func loadPuzzleFixture(t *testing.T) string {
+	b, err := os.ReadFile(filepath.Join("testdata", "puzzle-day1.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestParsePuzzleExamples(t *testing.T) {
+	examples := ParsePuzzleExamples(loadPuzzleFixture(t))
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "count", Expect: 3}, len(examples))
+	if len(examples) != 3 {
+		return
+	}
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "first input", Expect: dialExample}, examples[0].Input)
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "first answer", Expect: "3"}, examples[0].Answer.String())
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "second input", Expect: []string{"L50"}}, examples[1].Input)
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "em code answer", Expect: "1"}, examples[1].Answer.String())
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "part 2", Expect: 2}, examples[2].Part)
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "part 2 reuses input", Expect: true}, examples[2].Input == nil)
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "part 2 answer", Expect: "6"}, examples[2].Answer.String())
+
+	examples = ParsePuzzleExamples("<p>No article</p><pre><code>a &amp; b\n<em>c</em>\n</code></pre><p>Gives <code><em>x</em></code>.</p>")
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "bare page", Expect: []string{"a & b", "c"}}, examples[0].Input)
+	CheckTest(t, "puzzlepage.ParsePuzzleExamples", TTest{Name: "bare page answer", Expect: "x"}, examples[0].Answer.String())
+}
+
+func TestPartExamples(t *testing.T) {
+	examples := ParsePuzzleExamples(loadPuzzleFixture(t))
+	CheckTest(t, "puzzlepage.PartExamples", TTest{Name: "part 1", Expect: 2}, len(PartExamples(examples, 1)))
+	part2 := PartExamples(examples, 2)
+	CheckTest(t, "puzzlepage.PartExamples", TTest{Name: "part 2", Expect: 1}, len(part2))
+	CheckTest(t, "puzzlepage.PartExamples", TTest{Name: "part 2 input", Expect: dialExample}, part2[0].Input)
+	CheckTest(t, "puzzlepage.PartExamples", TTest{Name: "part 2 answer", Expect: "6"}, part2[0].Answer.String())
+}
+
+func TestWriteExamples(t *testing.T) {
+	dir := t.TempDir()
+	examples := PartExamples(ParsePuzzleExamples(loadPuzzleFixture(t)), 1)
+	//an empty sample input, as left by aoc new, is replaced
+	if err := os.WriteFile(filepath.Join(dir, DefaultShortInput), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	written, err := WriteExamples(dir, examples, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "puzzlepage.WriteExamples", TTest{Name: "written", Expect: []string{
+		filepath.Join(dir, ExamplesDir, "example-2.answer"),
+		filepath.Join(dir, ExamplesDir, "example-2.txt"),
+		filepath.Join(dir, DefaultShortInput),
+	}}, written)
+	lines, _ := ReadLines(filepath.Join(dir, DefaultShortInput))
+	CheckTest(t, "puzzlepage.WriteExamples", TTest{Name: "sample input", Expect: dialExample}, lines)
+
+	loaded, err := LoadExamples(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "puzzlepage.WriteExamples", TTest{Name: "loaded examples", Expect: 1}, len(loaded))
+	CheckTest(t, "puzzlepage.WriteExamples", TTest{Name: "loaded answer", Expect: "1"}, loaded[0].Expect.String())
+
+	_, err = WriteExamples(dir, examples, false)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected existing files to be kept, got %v", err)
+	}
+	if _, err := WriteExamples(dir, examples, true); err != nil {
+		t.Errorf("expected overwrite to replace files, got %v", err)
+	}
+	if _, err := WriteExamples(dir, nil, true); err == nil {
+		t.Error("expected an error writing no examples")
+	}
+}
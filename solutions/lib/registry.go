@@ -0,0 +1,228 @@
+package eulerlib
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// DefaultYear is the puzzle year assumed when a ProblemInfo does not set one.
+const DefaultYear = 2025
+
+// DefaultInput and DefaultShortInput name a solution's full and sample input
+// files unless its ProblemInfo says otherwise.
+const (
+	DefaultInput      = "input.txt"
+	DefaultShortInput = "input-test.txt"
+)
+
+// ProblemInfo describes a registered solution: which puzzle it solves, how to
+// construct it and where its input files live.
+type ProblemInfo struct {
+	Year  int
+	Day   int
+	Part  int
+	Title string
+	Tags  []string
+	// Dir is the directory holding the solution's input files. Register fills
+	// it in when left empty, with the directory of the calling source file if
+	// it exists on this machine and otherwise with FolderName, relative to
+	// the working directory.
+	Dir string
+	// Input and ShortInput name the full and sample input files within Dir,
+	// defaulting to "input.txt" and "input-test.txt".
+	Input      string
+	ShortInput string
+	// New returns a fresh Problem ready to generate an answer.
+	New func() Problem
+}
+
+// String identifies the problem as "year day D part P" for messages.
+func (m ProblemInfo) String() string {
+	return fmt.Sprintf("%d day %d part %d", m.Year, m.Day, m.Part)
+}
+
+// FolderName returns the conventional "dayX-Y" folder name for the problem.
+func (m ProblemInfo) FolderName() string {
+	return fmt.Sprintf("day%d-%d", m.Day, m.Part)
+}
+
+// InputPath returns the path of the full puzzle input.
+func (m ProblemInfo) InputPath() string {
+	return filepath.Join(m.Dir, m.Input)
+}
+
+// ShortInputPath returns the path of the sample puzzle input.
+func (m ProblemInfo) ShortInputPath() string {
+	return filepath.Join(m.Dir, m.ShortInput)
+}
+
+// InputSearch returns where the problem's input files are looked for.
+func (m ProblemInfo) InputSearch() InputSearch {
+	return InputSearch{Year: m.Year, Day: m.Day, Folder: m.FolderName(), Dir: m.Dir}
+}
+
+// LoadInput reads the problem's full input, or its sample input when short is
+// true, from the first location that has it.
+func (m ProblemInfo) LoadInput(short bool) ([]string, error) {
+	if short {
+		return m.InputSearch().Load(m.ShortInput)
+	}
+	return m.InputSearch().Load(m.Input)
+}
+
+// HasTag reports whether the problem was registered with the given tag.
+func (m ProblemInfo) HasTag(tag string) bool {
+	return slices.Contains(m.Tags, tag)
+}
+
+// problemNameRe matches the "Day X, Part Y" names returned by GetProblemName.
+var problemNameRe = regexp.MustCompile(`^Day (\d+), Part (\d+)$`)
+
+// folderNameRe matches the "dayX-Y" folder names used for solution packages.
+var folderNameRe = regexp.MustCompile(`^day(\d+)-(\d+)$`)
+
+// Registry holds the set of known solutions, keyed by year, day and part.
+type Registry struct {
+	mu       sync.RWMutex
+	problems []ProblemInfo
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{problems: make([]ProblemInfo, 0)}
+}
+
+// Add validates info, fills in defaults and stores it. It rejects duplicate
+// year/day/part combinations and solutions whose GetProblemName or folder
+// name disagree with the day and part being registered.
+func (r *Registry) Add(info ProblemInfo) error {
+	if info.Year == 0 {
+		info.Year = DefaultYear
+	}
+	if info.Input == "" {
+		info.Input = DefaultInput
+	}
+	if info.ShortInput == "" {
+		info.ShortInput = DefaultShortInput
+	}
+	if err := info.validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.problems {
+		if existing.Year == info.Year && existing.Day == info.Day && existing.Part == info.Part {
+			return fmt.Errorf("%s is already registered from %s", info, existing.Dir)
+		}
+	}
+	r.problems = append(r.problems, info)
+	slices.SortFunc(r.problems, func(a, b ProblemInfo) int {
+		return cmp.Or(cmp.Compare(a.Year, b.Year), cmp.Compare(a.Day, b.Day), cmp.Compare(a.Part, b.Part))
+	})
+	return nil
+}
+
+// validate checks the day and part are in range and consistent with the
+// solution's own name and folder.
+func (m ProblemInfo) validate() error {
+	if m.New == nil {
+		return fmt.Errorf("%s: New must be set", m)
+	}
+	if m.Day < 1 || m.Day > 25 {
+		return fmt.Errorf("%s: day must be between 1 and 25", m)
+	}
+	if m.Part < 1 || m.Part > 2 {
+		return fmt.Errorf("%s: part must be 1 or 2", m)
+	}
+	name := m.New().GetProblemName()
+	match := problemNameRe.FindStringSubmatch(name)
+	if match == nil {
+		return fmt.Errorf("%s: problem name %q is not of the form \"Day X, Part Y\"", m, name)
+	}
+	if match[1] != IntToStr(m.Day) || match[2] != IntToStr(m.Part) {
+		return fmt.Errorf("%s: problem name %q does not match", m, name)
+	}
+	if m.Dir != "" {
+		folder := filepath.Base(m.Dir)
+		if folderNameRe.MatchString(folder) && folder != m.FolderName() {
+			return fmt.Errorf("%s: registered from folder %s, expected %s", m, folder, m.FolderName())
+		}
+	}
+	return nil
+}
+
+// All returns every registered problem ordered by year, day and part.
+func (r *Registry) All() []ProblemInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.problems)
+}
+
+// Get returns the problem registered for the given year, day and part.
+func (r *Registry) Get(year, day, part int) (ProblemInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, info := range r.problems {
+		if info.Year == year && info.Day == day && info.Part == part {
+			return info, true
+		}
+	}
+	return ProblemInfo{}, false
+}
+
+// Filter returns the registered problems for which keep returns true, in
+// registry order.
+func (r *Registry) Filter(keep func(ProblemInfo) bool) []ProblemInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	filtered := []ProblemInfo{}
+	for _, info := range r.problems {
+		if keep(info) {
+			filtered = append(filtered, info)
+		}
+	}
+	return filtered
+}
+
+// Select returns the problems for a day of DefaultYear. A part of 0 selects
+// every part of the day.
+func (r *Registry) Select(day, part int) []ProblemInfo {
+	return r.Filter(func(info ProblemInfo) bool {
+		return info.Year == DefaultYear && info.Day == day && (part == 0 || info.Part == part)
+	})
+}
+
+// defaultRegistry holds the problems added through Register.
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry returns the registry populated by Register.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a solution to the default registry. It is intended to be
+// called from a solution package's init function, so Dir defaults to the
+// directory of the calling source file. A binary built with -trimpath, or
+// run away from its sources, has no usable source directory, and Dir is the
+// "dayX-Y" folder name instead, so that the binary finds inputs when run from
+// the solutions root. Register panics if the registration is invalid, in the
+// same way a duplicate flag or HTTP route would.
+func Register(info ProblemInfo) {
+	if info.Dir == "" {
+		if _, file, _, ok := runtime.Caller(1); ok {
+			info.Dir = sourceDir(file)
+		}
+		if info.Dir == "" {
+			info.Dir = info.FolderName()
+		}
+	}
+	if err := defaultRegistry.Add(info); err != nil {
+		panic("eulerlib: Register: " + err.Error())
+	}
+}
@@ -0,0 +1,93 @@
+package eulerlib
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TNamedProblem is a minimal Problem whose name can be set per test.
+type TNamedProblem struct {
+	Problem
+	name string
+}
+
+func (m *TNamedProblem) GetProblemName() string { return m.name }
+
+func newNamed(name string) func() Problem {
+	return func() Problem { return &TNamedProblem{name: name} }
+}
+
+func TestRegistryAddDefaults(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Add(ProblemInfo{Day: 3, Part: 1, Dir: "day3-1", New: newNamed("Day 3, Part 1")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	info, ok := r.Get(DefaultYear, 3, 1)
+	if !ok {
+		t.Fatal("expected day 3 part 1 to be registered")
+	}
+	CheckTest(t, "registry.Add", TTest{Name: "input", Expect: filepath.Join("day3-1", "input.txt")}, info.InputPath())
+	CheckTest(t, "registry.Add", TTest{Name: "short input", Expect: filepath.Join("day3-1", "input-test.txt")}, info.ShortInputPath())
+	CheckTest(t, "registry.Add", TTest{Name: "folder", Expect: "day3-1"}, info.FolderName())
+}
+
+func TestRegistryAddRejects(t *testing.T) {
+	tests := []TTest{
+		{Name: "no constructor", Input: ProblemInfo{Day: 1, Part: 1}, Expect: "New must be set"},
+		{Name: "day out of range", Input: ProblemInfo{Day: 26, Part: 1, New: newNamed("Day 26, Part 1")}, Expect: "day must be between 1 and 25"},
+		{Name: "part out of range", Input: ProblemInfo{Day: 1, Part: 3, New: newNamed("Day 1, Part 3")}, Expect: "part must be 1 or 2"},
+		{Name: "unparseable name", Input: ProblemInfo{Day: 1, Part: 1, New: newNamed("Test Problem")}, Expect: `"Test Problem" is not of the form`},
+		{Name: "mismatched name", Input: ProblemInfo{Day: 9, Part: 2, New: newNamed("Day 8, Part 2")}, Expect: `"Day 8, Part 2" does not match`},
+		{Name: "mismatched folder", Input: ProblemInfo{Day: 9, Part: 2, Dir: "/src/day8-2", New: newNamed("Day 9, Part 2")}, Expect: "registered from folder day8-2, expected day9-2"},
+	}
+	for _, test := range tests {
+		err := NewRegistry().Add(test.Input.(ProblemInfo))
+		if err == nil || !strings.Contains(err.Error(), test.Expect.(string)) {
+			ReportError(t, "registry.Add", test, err)
+		}
+	}
+}
+
+func TestRegistryAddRejectsDuplicate(t *testing.T) {
+	r := NewRegistry()
+	info := ProblemInfo{Day: 1, Part: 2, New: newNamed("Day 1, Part 2")}
+	if err := r.Add(info); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Add(info); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("expected duplicate registration to fail, got %v", err)
+	}
+	info.Year = 2024
+	if err := r.Add(info); err != nil {
+		t.Errorf("expected a different year to be accepted, got %v", err)
+	}
+}
+
+func TestRegistryOrderAndFilter(t *testing.T) {
+	r := NewRegistry()
+	_ = r.Add(ProblemInfo{Day: 10, Part: 1, Tags: []string{"search"}, New: newNamed("Day 10, Part 1")})
+	_ = r.Add(ProblemInfo{Day: 2, Part: 2, New: newNamed("Day 2, Part 2")})
+	_ = r.Add(ProblemInfo{Day: 2, Part: 1, Tags: []string{"search"}, New: newNamed("Day 2, Part 1")})
+
+	order := []string{}
+	for _, info := range r.All() {
+		order = append(order, info.FolderName())
+	}
+	CheckTest(t, "registry.All", TTest{Name: "order", Expect: []string{"day2-1", "day2-2", "day10-1"}}, order)
+
+	tagged := r.Filter(func(info ProblemInfo) bool { return info.HasTag("search") })
+	CheckTest(t, "registry.Filter", TTest{Name: "tag", Expect: 2}, len(tagged))
+	CheckTest(t, "registry.Select", TTest{Name: "whole day", Expect: 2}, len(r.Select(2, 0)))
+	CheckTest(t, "registry.Select", TTest{Name: "single part", Expect: 1}, len(r.Select(2, 2)))
+	CheckTest(t, "registry.Select", TTest{Name: "missing", Expect: 0}, len(r.Select(3, 0)))
+}
+
+func TestRegisterPanicsOnInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected Register to panic for an invalid problem")
+		}
+	}()
+	Register(ProblemInfo{Day: 0, Part: 1, New: newNamed("Day 0, Part 1")})
+}
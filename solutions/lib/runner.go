@@ -0,0 +1,328 @@
+package eulerlib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// RunResult records the outcome of generating a single Problem's answer,
+// including the answer it was expected to produce and how long it took.
+type RunResult struct {
+	Name     string
+	Answer   Answer
+	Expected Answer
+	// Record is the answers manifest entry Expected came from, if any.
+	Record   *AnswerRecord
+	Duration time.Duration
+	Err      error
+	// Log is the output of the problem's own Debugger when it was run by
+	// RunInfo with debugging enabled.
+	Log string
+}
+
+// expectation returns what the result's answer was checked against.
+func (m *RunResult) expectation() Expectation {
+	return Expectation{Answer: m.Expected, Record: m.Record}
+}
+
+// Passed reports whether the problem ran without error and produced its
+// expected answer.
+func (m *RunResult) Passed() bool {
+	return m.Err == nil && m.expectation().Known() && m.Answer.Equal(m.Expected)
+}
+
+// Unknown reports whether the problem ran without error but there is no
+// trusted answer to check it against, and it is not a known wrong answer.
+func (m *RunResult) Unknown() bool {
+	return m.Err == nil && !m.expectation().Known() && !m.expectation().IsWrong(m.Answer)
+}
+
+// Failed reports whether the problem errored or produced an answer that is
+// known to be wrong.
+func (m *RunResult) Failed() bool {
+	return !m.Passed() && !m.Unknown()
+}
+
+// TimedOut reports whether the problem was abandoned because it exceeded its
+// deadline.
+func (m *RunResult) TimedOut() bool {
+	var timeout *TimeoutError
+	return errors.As(m.Err, &timeout)
+}
+
+// Status returns a short label describing the result, suitable for tabular
+// output.
+func (m *RunResult) Status() string {
+	if m.TimedOut() {
+		return "TIMEOUT"
+	}
+	if m.Err != nil {
+		return "ERROR"
+	}
+	if m.expectation().IsWrong(m.Answer) {
+		return "WRONG"
+	}
+	if m.Unknown() {
+		return "unknown"
+	}
+	if m.Passed() {
+		return "pass"
+	}
+	return "FAIL"
+}
+
+// RunProblem generates the answer for a Problem, timing the call and
+// recovering from any panic raised by the solution. When short is true the
+// short answer path is used instead of the full input. Problems that
+// implement AnswerProblem have their errors recorded in the result.
+func RunProblem(problem Problem, short bool) RunResult {
+	return RunProblemContext(context.Background(), problem, short)
+}
+
+// RunProblemContext is RunProblem bounded by ctx: once ctx is done the result
+// records its cause, such as a TimeoutError from WithTimeout, instead of
+// waiting for the solution. ContextProblems are passed ctx so they can stop.
+func RunProblemContext(ctx context.Context, problem Problem, short bool) (result RunResult) {
+	result.Name = problem.GetProblemName()
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start)
+		if r := recover(); r != nil {
+			result.Err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	if short {
+		result.Expected = ParseAnswer(problem.GetShortAnswer())
+	} else {
+		result.Expected = ParseAnswer(problem.GetAnswer())
+	}
+	result.Answer, result.Err = solveContext(ctx, problem, short)
+	return result
+}
+
+// RunInfoContext runs a registered problem like RunProblemContext, checking
+// its answer against the answers manifest entry for its input when there is
+// one.
+func RunInfoContext(ctx context.Context, info ProblemInfo, short bool) RunResult {
+	problem := info.New()
+	result := RunProblemContext(ctx, problem, short)
+	filename := info.Input
+	if short {
+		filename = info.ShortInput
+	}
+	expect, err := expect(problem, info.InputSearch(), filename, short)
+	if err != nil && result.Err == nil {
+		result.Err = err
+	}
+	if expect.Record != nil {
+		result.Expected = expect.Answer
+		result.Record = expect.Record
+	}
+	return result
+}
+
+// RunOptions controls how RunInfo and RunAll run registered problems.
+type RunOptions struct {
+	// Short runs the sample input instead of the full input.
+	Short bool
+	// Timeout bounds each problem; zero or less means no limit.
+	Timeout time.Duration
+	// Workers is the number of problems RunAll runs at once. Zero or less
+	// means one per CPU.
+	Workers int
+	// Log sets the level and format of each problem's Debugger.
+	Log LogOptions
+	// LogOutput receives the problems' logs. When it is nil each problem's
+	// log is collected in its RunResult.Log instead.
+	LogOutput io.Writer
+	// Progress, when set, gets a task for each problem while it runs, under
+	// which the problem reports its own progress.
+	Progress *Progress
+}
+
+// RunInfo runs a registered problem like RunInfoContext within opts.Timeout,
+// giving it a Debugger of its own, labelled problem=dayX-Y, through its
+// context so that it shares no logging state with problems running
+// alongside it.
+func RunInfo(ctx context.Context, info ProblemInfo, opts RunOptions) RunResult {
+	ctx, cancel := WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	var log *syncBuffer
+	out := opts.LogOutput
+	if out == nil {
+		log = &syncBuffer{}
+		out = log
+	}
+	d, err := NewLogger(out, opts.Log)
+	if err != nil {
+		return RunResult{Name: info.New().GetProblemName(), Err: err}
+	}
+	ctx = WithDebugger(ctx, d.With("problem", info.FolderName()))
+	if opts.Progress != nil {
+		task := opts.Progress.Task(info.FolderName(), 0)
+		defer task.Done()
+		ctx = WithProgress(ctx, task)
+	}
+	result := RunInfoContext(ctx, info, opts.Short)
+	if log != nil {
+		result.Log = log.String()
+	}
+	return result
+}
+
+// RunReport is the outcome of RunAll.
+type RunReport struct {
+	// Results holds one result per problem, in the order they were given.
+	Results []RunResult
+	// Wall is the elapsed time of the whole run.
+	Wall time.Duration
+	// Summed is the total of the problems' own durations: roughly the CPU
+	// time spent, and what running them one after another would take.
+	Summed  time.Duration
+	Workers int
+}
+
+// RunAll runs problems on a pool of opts.Workers goroutines using RunInfo.
+// Results are reported in the order of problems, whatever order they finish
+// in. Once ctx is cancelled the problems not yet started are reported with
+// its cause without being run.
+//
+// Problems that are not ContextProblems cannot be stopped when they time out
+// and go on using CPU and memory in the background, so RunAll runs them one
+// at a time rather than letting several pile up. One that has timed out
+// still holds up the next until its abandoned solve returns, though its
+// result is reported straight away. ContextProblems run alongside them on
+// the remaining workers.
+//
+// With a single worker logs are written to opts.LogOutput as they happen.
+// With more, each problem's log is held back until it and every problem
+// before it have finished, so that logs appear in the same order as the
+// results rather than interleaved.
+func RunAll(ctx context.Context, problems []ProblemInfo, opts RunOptions) RunReport {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = max(min(workers, len(problems)), 1)
+	report := RunReport{Results: make([]RunResult, len(problems)), Workers: workers}
+
+	out := opts.LogOutput
+	if out != nil {
+		out = SyncWriter(out)
+		opts.LogOutput = out
+		if workers > 1 {
+			opts.LogOutput = nil
+		}
+	}
+	var mu sync.Mutex
+	finished := make([]bool, len(problems))
+	flushed := 0
+	finish := func(i int) {
+		if out == nil || workers == 1 {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		finished[i] = true
+		for ; flushed < len(problems) && finished[flushed]; flushed++ {
+			io.WriteString(out, report.Results[flushed].Log)
+			report.Results[flushed].Log = ""
+		}
+	}
+
+	serial := make(chan struct{}, 1)
+	run := func(info ProblemInfo) RunResult {
+		if _, ok := info.New().(ContextProblem); ok {
+			return RunInfo(ctx, info, opts)
+		}
+		select {
+		case serial <- struct{}{}:
+		case <-ctx.Done():
+			//reported with ctx's cause without being solved
+			return RunInfo(ctx, info, opts)
+		}
+		var solves sync.WaitGroup
+		result := RunInfo(withSolves(ctx, &solves), info, opts)
+		//a solve abandoned when it timed out keeps its turn until it returns
+		go func() {
+			solves.Wait()
+			<-serial
+		}()
+		return result
+	}
+
+	start := time.Now()
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				report.Results[i] = run(problems[i])
+				finish(i)
+			}
+		}()
+	}
+	for i := range problems {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	report.Wall = time.Since(start)
+
+	for _, r := range report.Results {
+		report.Summed += r.Duration
+	}
+	return report
+}
+
+// SyncWriter returns a writer that serialises writes to w, for a log output
+// shared by several Debuggers. It returns w itself if it is already one.
+func SyncWriter(w io.Writer) io.Writer {
+	if _, ok := w.(*lockedWriter); ok {
+		return w
+	}
+	return &lockedWriter{w: w}
+}
+
+// lockedWriter is the writer returned by SyncWriter.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write writes p to the underlying writer.
+func (m *lockedWriter) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.w.Write(p)
+}
+
+// syncBuffer is a bytes.Buffer that can be written from several goroutines,
+// since a problem may log from its own worker goroutines or carry on after
+// it has been abandoned.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write appends p to the buffer.
+func (m *syncBuffer) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.buf.Write(p)
+}
+
+// String returns the buffer's contents so far.
+func (m *syncBuffer) String() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.buf.String()
+}
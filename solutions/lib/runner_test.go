@@ -0,0 +1,190 @@
+package eulerlib
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunProblem(t *testing.T) {
+	result := RunProblem(&TTestProblem{}, false)
+	CheckTest(t, "runner.RunProblem", TTest{Name: "answer", Expect: "1234"}, result.Answer.String())
+	CheckTest(t, "runner.RunProblem", TTest{Name: "expected", Expect: "1234"}, result.Expected.String())
+	CheckTest(t, "runner.RunProblem", TTest{Name: "status", Expect: "pass"}, result.Status())
+	if result.Duration <= 0 {
+		t.Errorf("expected a positive duration, got %v", result.Duration)
+	}
+}
+
+func TestRunProblemShort(t *testing.T) {
+	result := RunProblem(&TShortProblem{}, true)
+	CheckTest(t, "runner.RunProblemShort", TTest{Name: "answer", Expect: "SHORT_ANSWER"}, result.Answer.String())
+	CheckTest(t, "runner.RunProblemShort", TTest{Name: "passed", Expect: true}, result.Passed())
+}
+
+func TestRunProblemMismatch(t *testing.T) {
+	result := RunProblem(&TShortProblem{}, false)
+	CheckTest(t, "runner.RunProblemMismatch", TTest{Name: "status", Expect: "FAIL"}, result.Status())
+}
+
+func TestRunProblemRecoversPanic(t *testing.T) {
+	result := RunProblem(&TPanickingShortProblem{}, true)
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "short answer not implemented") {
+		t.Errorf("expected a recovered panic error, got %v", result.Err)
+	}
+	CheckTest(t, "runner.RunProblemRecoversPanic", TTest{Name: "status", Expect: "ERROR"}, result.Status())
+}
+
+// TLoggingProblem logs through its context's Debugger and finishes after
+// delay, so that problems run in parallel finish out of order.
+type TLoggingProblem struct {
+	Problem
+	day   int
+	delay time.Duration
+}
+
+func (m *TLoggingProblem) GetProblemName() string { return fmt.Sprintf("Day %d, Part 1", m.day) }
+
+func (m *TLoggingProblem) GetAnswer() string { return IntToStr(m.day) }
+
+func (m *TLoggingProblem) SolveContext(ctx context.Context, short bool) (Answer, error) {
+	DebuggerFrom(ctx).Logf("solving day %d", m.day)
+	time.Sleep(m.delay)
+	return IntAnswer(m.day), nil
+}
+
+func loggingProblems(n int) []ProblemInfo {
+	problems := make([]ProblemInfo, n)
+	for i := range problems {
+		day := i + 1
+		delay := time.Duration(n-i) * 10 * time.Millisecond
+		problems[i] = ProblemInfo{Year: DefaultYear, Day: day, Part: 1, New: func() Problem {
+			return &TLoggingProblem{day: day, delay: delay}
+		}}
+	}
+	return problems
+}
+
+func TestRunAll(t *testing.T) {
+	problems := loggingProblems(4)
+	report := RunAll(context.Background(), problems, RunOptions{Workers: 4, Log: LogOptions{Level: slog.LevelDebug, Format: LogJSON}})
+	CheckTest(t, "runner.RunAll", TTest{Name: "workers", Expect: 4}, report.Workers)
+	var summed time.Duration
+	for i, r := range report.Results {
+		day := i + 1
+		CheckTest(t, "runner.RunAll", TTest{Name: "order", Expect: fmt.Sprintf("Day %d, Part 1", day)}, r.Name)
+		CheckTest(t, "runner.RunAll", TTest{Name: "status", Expect: "pass"}, r.Status())
+		var record map[string]any
+		if err := json.Unmarshal([]byte(r.Log), &record); err != nil {
+			t.Fatalf("expected one JSON log record, got %q", r.Log)
+		}
+		CheckTest(t, "runner.RunAll", TTest{Name: "log", Expect: fmt.Sprintf("solving day %d", day)}, record["msg"])
+		CheckTest(t, "runner.RunAll", TTest{Name: "problem", Expect: fmt.Sprintf("day%d-1", day)}, record["problem"])
+		summed += r.Duration
+	}
+	CheckTest(t, "runner.RunAll", TTest{Name: "summed", Expect: summed}, report.Summed)
+	if report.Wall >= report.Summed {
+		t.Errorf("expected parallel wall time %s to be below the summed %s", report.Wall, report.Summed)
+	}
+}
+
+func TestRunAllNoDebug(t *testing.T) {
+	report := RunAll(context.Background(), loggingProblems(2), RunOptions{Workers: 1})
+	CheckTest(t, "runner.RunAllNoDebug", TTest{Name: "workers", Expect: 1}, report.Workers)
+	for _, r := range report.Results {
+		CheckTest(t, "runner.RunAllNoDebug", TTest{Name: "log", Expect: ""}, r.Log)
+	}
+}
+
+func TestRunAllLogOutput(t *testing.T) {
+	for _, workers := range []int{1, 3} {
+		var out bytes.Buffer
+		opts := RunOptions{Workers: workers, Log: LogOptions{Level: slog.LevelDebug}, LogOutput: &out}
+		report := RunAll(context.Background(), loggingProblems(3), opts)
+		//the last problem finishes first, but its log still comes last
+		lines := SplitLines(strings.TrimSuffix(out.String(), "\n"))
+		CheckTest(t, "runner.RunAllLogOutput", TTest{Name: "lines", Expect: 3}, len(lines))
+		for i, line := range lines {
+			if !strings.Contains(line, fmt.Sprintf("problem=day%d-1", i+1)) {
+				t.Errorf("%d workers: expected line %d to be from day %d, got %q", workers, i, i+1, line)
+			}
+		}
+		for _, r := range report.Results {
+			CheckTest(t, "runner.RunAllLogOutput", TTest{Name: "result log", Expect: ""}, r.Log)
+		}
+	}
+}
+
+func TestRunAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report := RunAll(ctx, loggingProblems(3), RunOptions{Workers: 2})
+	for _, r := range report.Results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("expected %s to be cancelled, got %v", r.Name, r.Err)
+		}
+	}
+}
+
+// TUncancellableProblem cannot be cancelled, and records how many of its kind run
+// at once. It takes 10ms, or sleep if that is set.
+type TUncancellableProblem struct {
+	TTestProblem
+	running, most *atomic.Int32
+	sleep         time.Duration
+}
+
+func (m *TUncancellableProblem) GenerateAnswer() string {
+	n := m.running.Add(1)
+	defer m.running.Add(-1)
+	for {
+		most := m.most.Load()
+		if n <= most || m.most.CompareAndSwap(most, n) {
+			break
+		}
+	}
+	time.Sleep(cmp.Or(m.sleep, 10*time.Millisecond))
+	return m.TTestProblem.GenerateAnswer()
+}
+
+func TestRunAllSerialisesContextUnaware(t *testing.T) {
+	var running, most atomic.Int32
+	problems := loggingProblems(2)
+	for day := 3; day <= 5; day++ {
+		problems = append(problems, ProblemInfo{Year: DefaultYear, Day: day, Part: 1, New: func() Problem {
+			return &TUncancellableProblem{running: &running, most: &most}
+		}})
+	}
+	report := RunAll(context.Background(), problems, RunOptions{Workers: 4})
+	for _, r := range report.Results {
+		if r.Err != nil {
+			t.Errorf("%s: unexpected error %v", r.Name, r.Err)
+		}
+	}
+	CheckTest(t, "runner.RunAllSerialisesContextUnaware", TTest{Name: "at once", Expect: int32(1)}, most.Load())
+
+	//timed out solves carry on in the background, and must still take turns
+	most.Store(0)
+	problems = problems[:0]
+	for day := 1; day <= 3; day++ {
+		problems = append(problems, ProblemInfo{Year: DefaultYear, Day: day, Part: 1, New: func() Problem {
+			return &TUncancellableProblem{running: &running, most: &most, sleep: 30 * time.Millisecond}
+		}})
+	}
+	report = RunAll(context.Background(), problems, RunOptions{Workers: 3, Timeout: 5 * time.Millisecond})
+	for _, r := range report.Results {
+		CheckTest(t, "runner.RunAllSerialisesContextUnaware", TTest{Name: r.Name + " timed out", Expect: true}, r.TimedOut())
+	}
+	for running.Load() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	CheckTest(t, "runner.RunAllSerialisesContextUnaware", TTest{Name: "at once after timeouts", Expect: int32(1)}, most.Load())
+}
@@ -0,0 +1,338 @@
+package eulerlib
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// SearchResult is the outcome of BFS, Dijkstra or AStar over states of type
+// S. A state is whatever the search needs to tell positions apart: a Point
+// for a plain maze, or a struct such as Heading when turning costs extra or
+// the number of steps in a straight line is limited.
+type SearchResult[S comparable] struct {
+	// Dist is the least cost found to each state the search reached. It is
+	// exact for every state when the search ran to completion, and for the
+	// states on the best paths when it stopped at a goal.
+	Dist map[S]int
+	// Goals are the goal states reached at the least cost, in the order the
+	// search reached them. It is empty if no goal was reached or the search
+	// had no goal.
+	Goals []S
+	// prev lists every state each state is reached from on a least-cost path.
+	prev map[S][]S
+}
+
+// Found reports whether the search reached a goal.
+func (m *SearchResult[S]) Found() bool {
+	return len(m.Goals) > 0
+}
+
+// Cost returns the least cost of reaching a goal, or -1 if none was reached.
+func (m *SearchResult[S]) Cost() int {
+	if !m.Found() {
+		return -1
+	}
+	return m.Dist[m.Goals[0]]
+}
+
+// Path returns a least-cost path from a start state to to, both included, or
+// nil if to was not reached.
+func (m *SearchResult[S]) Path(to S) []S {
+	if _, ok := m.Dist[to]; !ok {
+		return nil
+	}
+	path := []S{to}
+	for prev := m.prev[to]; len(prev) > 0; prev = m.prev[prev[0]] {
+		path = append(path, prev[0])
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// AllPaths returns every least-cost path from a start state to to. There can
+// be exponentially many; OnPaths finds the states on them without listing
+// each one.
+func (m *SearchResult[S]) AllPaths(to S) [][]S {
+	if _, ok := m.Dist[to]; !ok {
+		return nil
+	}
+	var paths [][]S
+	var walk func(s S, suffix []S)
+	walk = func(s S, suffix []S) {
+		suffix = append(suffix, s)
+		if len(m.prev[s]) == 0 {
+			path := slices.Clone(suffix)
+			slices.Reverse(path)
+			paths = append(paths, path)
+			return
+		}
+		for _, p := range m.prev[s] {
+			walk(p, suffix)
+		}
+	}
+	walk(to, nil)
+	return paths
+}
+
+// OnPaths returns the set of states on any least-cost path to one of the
+// states in to.
+func (m *SearchResult[S]) OnPaths(to ...S) map[S]bool {
+	on := map[S]bool{}
+	pending := slices.Clone(to)
+	for len(pending) > 0 {
+		s := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if _, ok := m.Dist[s]; !ok || on[s] {
+			continue
+		}
+		on[s] = true
+		pending = append(pending, m.prev[s]...)
+	}
+	return on
+}
+
+// BestPath returns a least-cost path to the first goal, or nil if no goal
+// was reached.
+func (m *SearchResult[S]) BestPath() []S {
+	if !m.Found() {
+		return nil
+	}
+	return m.Path(m.Goals[0])
+}
+
+// AllBestPaths returns every least-cost path to any of the goals.
+func (m *SearchResult[S]) AllBestPaths() [][]S {
+	var paths [][]S
+	for _, g := range m.Goals {
+		paths = append(paths, m.AllPaths(g)...)
+	}
+	return paths
+}
+
+// BFS searches outwards from starts, where every move costs 1, following the
+// moves next returns from each state. It stops once every goal state at the
+// least distance has been reached, or explores every reachable state if goal
+// is nil.
+func BFS[S comparable](starts []S, next func(s S) iter.Seq[S], goal func(s S) bool) *SearchResult[S] {
+	m := newSearchResult[S]()
+	queue := make([]S, 0, len(starts))
+	for _, s := range starts {
+		if _, ok := m.Dist[s]; !ok {
+			m.Dist[s] = 0
+			queue = append(queue, s)
+		}
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		d := m.Dist[s]
+		if m.Found() && d > m.Cost() {
+			break
+		}
+		if goal != nil && goal(s) {
+			m.Goals = append(m.Goals, s)
+		}
+		for n := range next(s) {
+			nd, seen := m.Dist[n]
+			switch {
+			case !seen:
+				m.Dist[n] = d + 1
+				m.prev[n] = []S{s}
+				queue = append(queue, n)
+			case nd == d+1:
+				m.prev[n] = append(m.prev[n], s)
+			}
+		}
+	}
+	return m
+}
+
+// Dijkstra searches outwards from starts in order of cost, following the
+// moves next returns from each state along with their costs, which must not
+// be negative. It stops once every goal state at the least cost has been
+// reached, or explores every reachable state if goal is nil.
+func Dijkstra[S comparable](starts []S, next func(s S) iter.Seq2[S, int], goal func(s S) bool) *SearchResult[S] {
+	return AStar(starts, next, goal, nil)
+}
+
+// AStar is Dijkstra guided towards the goal by heuristic, an estimate of the
+// least cost from a state to a goal. The heuristic must never overestimate,
+// and must not drop by more than the cost of a move, for the result to be
+// the least cost; ManhattanTo is such a heuristic for moves of cost 1 or
+// more between neighbouring cells. A nil heuristic makes it Dijkstra.
+func AStar[S comparable](starts []S, next func(s S) iter.Seq2[S, int], goal func(s S) bool, heuristic func(s S) int) *SearchResult[S] {
+	if heuristic == nil {
+		heuristic = func(S) int { return 0 }
+	}
+	m := newSearchResult[S]()
+	queue := &searchQueue[S]{}
+	for _, s := range starts {
+		if _, ok := m.Dist[s]; !ok {
+			m.Dist[s] = 0
+			heap.Push(queue, searchItem[S]{state: s, cost: 0, priority: heuristic(s)})
+		}
+	}
+	settled := map[S]bool{}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(searchItem[S])
+		s, d := item.state, item.cost
+		if settled[s] || d > m.Dist[s] {
+			continue
+		}
+		if m.Found() && item.priority > m.Cost() {
+			break
+		}
+		settled[s] = true
+		if goal != nil && goal(s) {
+			m.Goals = append(m.Goals, s)
+		}
+		for n, cost := range next(s) {
+			if settled[n] {
+				continue
+			}
+			nd := d + cost
+			cur, seen := m.Dist[n]
+			switch {
+			case !seen || nd < cur:
+				m.Dist[n] = nd
+				m.prev[n] = []S{s}
+				heap.Push(queue, searchItem[S]{state: n, cost: nd, priority: nd + heuristic(n)})
+			case nd == cur:
+				m.prev[n] = append(m.prev[n], s)
+			}
+		}
+	}
+	return m
+}
+
+// newSearchResult returns an empty result for a search to fill in.
+func newSearchResult[S comparable]() *SearchResult[S] {
+	return &SearchResult[S]{Dist: map[S]int{}, prev: map[S][]S{}}
+}
+
+// searchItem is a state waiting in a searchQueue, with the cost of reaching
+// it and the cost plus heuristic it is ordered by.
+type searchItem[S comparable] struct {
+	state    S
+	cost     int
+	priority int
+}
+
+// searchQueue is a min-heap of searchItems by priority, for container/heap.
+type searchQueue[S comparable] []searchItem[S]
+
+func (q searchQueue[S]) Len() int { return len(q) }
+
+func (q searchQueue[S]) Less(i, j int) bool {
+	//prefer the costlier of equal priorities, as it is nearer the goal
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].cost > q[j].cost
+}
+
+func (q searchQueue[S]) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *searchQueue[S]) Push(x any) { *q = append(*q, x.(searchItem[S])) }
+
+func (q *searchQueue[S]) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// Heading is a search state for puzzles where the way a position was entered
+// matters, such as when turning costs extra. Steps can count moves made in a
+// straight line, for puzzles that limit or require them.
+type Heading struct {
+	Pos   Point
+	Dir   TDirection
+	Steps int
+}
+
+// ManhattanTo returns a heuristic for AStar: the Manhattan distance from a
+// point to goal.
+func ManhattanTo(goal Point) func(p Point) int {
+	return func(p Point) int { return p.Manhattan(goal) }
+}
+
+// GridMoves returns the moves for a search over the cells of g: a step to
+// each of the 4 neighbouring cells that passable accepts, costing
+// cost(from, to). A nil passable accepts every cell and a nil cost makes
+// every step cost 1.
+func GridMoves[T comparable](g *Grid[T], passable func(p Point, v T) bool, cost func(from, to Point) int) func(p Point) iter.Seq2[Point, int] {
+	return func(p Point) iter.Seq2[Point, int] {
+		return func(yield func(Point, int) bool) {
+			for n := range p.Neighbours4In(g) {
+				if passable != nil && !passable(n, g.At(n.X, n.Y)) {
+					continue
+				}
+				c := 1
+				if cost != nil {
+					c = cost(p, n)
+				}
+				if !yield(n, c) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GridSteps returns the moves for a BFS over the cells of g, as GridMoves
+// does.
+func GridSteps[T comparable](g *Grid[T], passable func(p Point, v T) bool) func(p Point) iter.Seq[Point] {
+	return unitMoves(GridMoves(g, passable, nil))
+}
+
+// Moves returns the moves for a search over the cells of the grid, as
+// GridMoves does for a Grid.
+func (m *TGrid) Moves(passable func(p Point, v any) bool, cost func(from, to Point) int) func(p Point) iter.Seq2[Point, int] {
+	return func(p Point) iter.Seq2[Point, int] {
+		return func(yield func(Point, int) bool) {
+			for n := range p.Neighbours4In(m) {
+				if passable != nil && !passable(n, m.GetAt(n)) {
+					continue
+				}
+				c := 1
+				if cost != nil {
+					c = cost(p, n)
+				}
+				if !yield(n, c) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Steps returns the moves for a BFS over the cells of the grid, as Moves
+// does.
+func (m *TGrid) Steps(passable func(p Point, v any) bool) func(p Point) iter.Seq[Point] {
+	return unitMoves(m.Moves(passable, nil))
+}
+
+// unitMoves drops the costs from moves, for BFS.
+func unitMoves[S any](moves func(s S) iter.Seq2[S, int]) func(s S) iter.Seq[S] {
+	return func(s S) iter.Seq[S] {
+		return func(yield func(S) bool) {
+			for n := range moves(s) {
+				if !yield(n) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DistanceGrid lays the distances a search over grid cells found out as a
+// grid of the given size, with unreached for the cells it did not reach.
+func DistanceGrid(r *SearchResult[Point], size GridSize, unreached int) *Grid[int] {
+	g := NewGridFilled(size.Width, size.Height, unreached)
+	for p, d := range r.Dist {
+		g.SetAt(p, d)
+	}
+	return g
+}
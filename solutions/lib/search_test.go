@@ -0,0 +1,172 @@
+package eulerlib
+
+import (
+	"iter"
+	"testing"
+)
+
+func TestBFS(t *testing.T) {
+	g := MustParseRuneGrid([]string{
+		"S.#.",
+		"..#.",
+		"...E",
+	})
+	start, _ := g.Find('S')
+	end, _ := g.Find('E')
+	open := func(p Point, v rune) bool { return v != '#' }
+
+	r := BFS([]Point{start}, GridSteps(g, open), func(p Point) bool { return p == end })
+	CheckTest(t, "BFS", TTest{Name: "cost", Expect: 5}, r.Cost())
+	CheckTest(t, "BFS", TTest{Name: "path length", Expect: 6}, len(r.BestPath()))
+	CheckTest(t, "BFS", TTest{Name: "all paths", Expect: 3}, len(r.AllBestPaths()))
+	for _, path := range r.AllBestPaths() {
+		CheckTest(t, "BFS", TTest{Name: "path ends", Expect: []Point{start, end}}, []Point{path[0], path[len(path)-1]})
+	}
+
+	//without a goal the whole grid is explored
+	all := BFS([]Point{start}, GridSteps(g, open), nil)
+	CheckTest(t, "BFS", TTest{Name: "not found", Expect: -1}, all.Cost())
+	CheckTest(t, "DistanceGrid", TTest{Name: "distances", Expect: "01-7\n12-6\n2345\n"}, distanceString(DistanceGrid(all, g.Size(), -1)))
+
+	walled := BFS([]Point{start}, GridSteps(g, func(p Point, v rune) bool { return v == '.' }), func(p Point) bool { return p == end })
+	CheckTest(t, "BFS", TTest{Name: "unreachable", Expect: false}, walled.Found())
+	CheckTest(t, "BFS", TTest{Name: "no path", Expect: 0}, len(walled.Path(end)))
+}
+
+// distanceString renders a grid of small distances, with - for -1.
+func distanceString(g *Grid[int]) string {
+	s := ""
+	for _, row := range g.Rows() {
+		for _, d := range row {
+			if d < 0 {
+				s += "-"
+			} else {
+				s += IntToStr(d)
+			}
+		}
+		s += "\n"
+	}
+	return s
+}
+
+func TestDijkstraTurnCost(t *testing.T) {
+	//the sample maze from 2024 day 16: turning costs 1000, a step costs 1
+	g := MustParseRuneGrid([]string{
+		"###############",
+		"#.......#....E#",
+		"#.#.###.#.###.#",
+		"#.....#.#...#.#",
+		"#.###.#####.#.#",
+		"#.#.#.......#.#",
+		"#.#.#####.###.#",
+		"#...........#.#",
+		"###.#.#####.#.#",
+		"#...#.....#.#.#",
+		"#.#.#.###.#.#.#",
+		"#.....#...#.#.#",
+		"#.###.#.#.#.#.#",
+		"#S..#.....#...#",
+		"###############",
+	})
+	start, _ := g.Find('S')
+	end, _ := g.Find('E')
+	next := func(h Heading) iter.Seq2[Heading, int] {
+		return func(yield func(Heading, int) bool) {
+			if ahead := h.Pos.Move(h.Dir); g.At(ahead.X, ahead.Y) != '#' {
+				if !yield(Heading{Pos: ahead, Dir: h.Dir}, 1) {
+					return
+				}
+			}
+			if !yield(Heading{Pos: h.Pos, Dir: h.Dir.TurnLeft()}, 1000) {
+				return
+			}
+			yield(Heading{Pos: h.Pos, Dir: h.Dir.TurnRight()}, 1000)
+		}
+	}
+	r := Dijkstra([]Heading{{Pos: start, Dir: RightDirection}}, next, func(h Heading) bool { return h.Pos == end })
+	CheckTest(t, "Dijkstra", TTest{Name: "cost", Expect: 7036}, r.Cost())
+
+	tiles := map[Point]bool{}
+	for h := range r.OnPaths(r.Goals...) {
+		tiles[h.Pos] = true
+	}
+	CheckTest(t, "Dijkstra", TTest{Name: "tiles on best paths", Expect: 45}, len(tiles))
+	path := r.BestPath()
+	CheckTest(t, "Dijkstra", TTest{Name: "path", Expect: []Point{start, end}}, []Point{path[0].Pos, path[len(path)-1].Pos})
+}
+
+func TestAStarMomentum(t *testing.T) {
+	//the sample city from 2023 day 17: a crucible must move between
+	//minRun and maxRun blocks in a straight line before turning
+	g := MustParseDigitGrid([]string{
+		"2413432311323",
+		"3215453535623",
+		"3255245654254",
+		"3446585845452",
+		"4546657867536",
+		"1438598798454",
+		"4457876987766",
+		"3637877979653",
+		"4654967986887",
+		"4564679986453",
+		"1224686865563",
+		"2546548887735",
+		"4322674655533",
+	})
+	end := Point{g.Width - 1, g.Height - 1}
+	heatLoss := func(minRun, maxRun int) int {
+		next := func(h Heading) iter.Seq2[Heading, int] {
+			return func(yield func(Heading, int) bool) {
+				for _, dir := range []TDirection{h.Dir, h.Dir.TurnLeft(), h.Dir.TurnRight()} {
+					steps := 1
+					if dir == h.Dir {
+						steps = h.Steps + 1
+					} else if h.Steps < minRun {
+						continue
+					}
+					p := h.Pos.Move(dir)
+					if steps > maxRun || !g.Contains(p) {
+						continue
+					}
+					if !yield(Heading{Pos: p, Dir: dir, Steps: steps}, g.At(p.X, p.Y)) {
+						return
+					}
+				}
+			}
+		}
+		starts := []Heading{{Dir: RightDirection}, {Dir: DownDirection}}
+		goal := func(h Heading) bool { return h.Pos == end && h.Steps >= minRun }
+		heuristic := func(h Heading) int { return h.Pos.Manhattan(end) }
+		r := AStar(starts, next, goal, heuristic)
+		if d := Dijkstra(starts, next, goal); d.Cost() != r.Cost() {
+			t.Errorf("AStar cost %d differs from Dijkstra's %d", r.Cost(), d.Cost())
+		}
+		return r.Cost()
+	}
+	CheckTest(t, "AStar", TTest{Name: "crucible", Expect: 102}, heatLoss(0, 3))
+	CheckTest(t, "AStar", TTest{Name: "ultra crucible", Expect: 94}, heatLoss(4, 10))
+}
+
+func TestSearchTGrid(t *testing.T) {
+	g := &TGrid{}
+	g.Init()
+	g.ParseTable([]string{"S..", "##.", "E.."}, false)
+	start, _ := g.Find('S')
+	end, _ := g.Find('E')
+	open := func(p Point, v any) bool { return v != '#' }
+
+	r := BFS([]Point{start}, g.Steps(open), func(p Point) bool { return p == end })
+	CheckTest(t, "TGrid.Steps", TTest{Name: "path", Expect: []Point{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}, {1, 2}, {0, 2}}}, r.BestPath())
+
+	//stepping down costs 5, so the two searches agree on the path but not
+	//the cost
+	downhill := func(from, to Point) int {
+		if to.Y > from.Y {
+			return 5
+		}
+		return 1
+	}
+	d := AStar([]Point{start}, g.Moves(open, downhill), func(p Point) bool { return p == end }, ManhattanTo(end))
+	CheckTest(t, "TGrid.Moves", TTest{Name: "cost", Expect: 14}, d.Cost())
+	CheckTest(t, "TGrid.Moves", TTest{Name: "path", Expect: r.BestPath()}, d.BestPath())
+}
@@ -0,0 +1,247 @@
+package eulerlib
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryFile is the name of the answer history, kept beside the answers
+// manifest.
+const HistoryFile = "answer-history.jsonl"
+
+// Verdict is the site's response to a submitted answer.
+type Verdict string
+
+const (
+	// VerdictCorrect means the answer was accepted.
+	VerdictCorrect Verdict = "correct"
+	// VerdictTooHigh and VerdictTooLow mean the answer was wrong, with a
+	// hint about which way.
+	VerdictTooHigh Verdict = "too high"
+	VerdictTooLow  Verdict = "too low"
+	// VerdictWrong means the answer was wrong with no hint.
+	VerdictWrong Verdict = "wrong"
+	// VerdictWait means an answer was submitted too recently and this one
+	// was not checked.
+	VerdictWait Verdict = "wait"
+	// VerdictSolved means the part was already solved, or is not unlocked,
+	// so the answer was not checked.
+	VerdictSolved Verdict = "already solved"
+	// VerdictUnknown means the response was not recognised.
+	VerdictUnknown Verdict = "unknown"
+)
+
+// IsWrong reports whether the verdict rejects the answer.
+func (v Verdict) IsWrong() bool {
+	return v == VerdictWrong || v == VerdictTooHigh || v == VerdictTooLow
+}
+
+// SubmitResult is a parsed response to a submitted answer.
+type SubmitResult struct {
+	Verdict Verdict
+	// Wait is how long the site says to wait before the next submission.
+	Wait time.Duration
+	// Message is the text of the response.
+	Message string
+}
+
+var (
+	articleRe  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	spacesRe   = regexp.MustCompile(`\s+`)
+	leftRe     = regexp.MustCompile(`You have (?:(\d+)m ?)?(?:(\d+)s )?left to wait`)
+	waitMinsRe = regexp.MustCompile(`(?i)wait (one|\d+) minutes? before trying again`)
+)
+
+// ParseSubmitResponse reads the verdict, and any wait it imposes, from the
+// page the site returns for a submitted answer.
+func ParseSubmitResponse(page string) SubmitResult {
+	if match := articleRe.FindStringSubmatch(page); match != nil {
+		page = match[1]
+	}
+	text := html.UnescapeString(tagRe.ReplaceAllString(page, " "))
+	text = strings.TrimSpace(spacesRe.ReplaceAllString(text, " "))
+	result := SubmitResult{Verdict: VerdictUnknown, Message: text}
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		result.Verdict = VerdictCorrect
+	case strings.Contains(text, "That's not the right answer"):
+		result.Verdict = VerdictWrong
+		if strings.Contains(text, "answer is too high") {
+			result.Verdict = VerdictTooHigh
+		} else if strings.Contains(text, "answer is too low") {
+			result.Verdict = VerdictTooLow
+		}
+	case strings.Contains(text, "You gave an answer too recently"):
+		result.Verdict = VerdictWait
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		result.Verdict = VerdictSolved
+	}
+	if match := leftRe.FindStringSubmatch(text); match != nil {
+		mins, _ := strconv.Atoi(match[1])
+		secs, _ := strconv.Atoi(match[2])
+		result.Wait = time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+	} else if match := waitMinsRe.FindStringSubmatch(text); match != nil {
+		mins, err := strconv.Atoi(match[1])
+		if err != nil {
+			mins = 1
+		}
+		result.Wait = time.Duration(mins) * time.Minute
+	}
+	return result
+}
+
+// Submit posts answer for a year, day and part and parses the response. It
+// is rate limited with the Fetcher's other requests. Check the answer against
+// an AnswerHistory first: the site penalises wrong answers with a growing
+// wait.
+func (m *Fetcher) Submit(ctx context.Context, year, day, part int, answer Answer) (SubmitResult, error) {
+	if answer.IsEmpty() {
+		return SubmitResult{}, errors.New("cannot submit an empty answer")
+	}
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer.String()}}
+	page, err := m.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day), form)
+	if err != nil {
+		return SubmitResult{}, err
+	}
+	return ParseSubmitResponse(string(page)), nil
+}
+
+// Attempt is one submission in the answer history.
+type Attempt struct {
+	Time    time.Time     `json:"time"`
+	Year    int           `json:"year"`
+	Day     int           `json:"day"`
+	Part    int           `json:"part"`
+	Answer  Answer        `json:"answer"`
+	Verdict Verdict       `json:"verdict"`
+	Wait    time.Duration `json:"wait,omitempty"`
+	Message string        `json:"message,omitempty"`
+}
+
+// AnswerHistory is every answer submitted, stored one JSON Attempt per line
+// so that recording an attempt only ever appends.
+type AnswerHistory struct {
+	Path     string
+	Attempts []Attempt
+}
+
+// LoadHistory reads the history at path. A missing file gives an empty
+// history that Record will create.
+func LoadHistory(path string) (*AnswerHistory, error) {
+	h := &AnswerHistory{Path: path}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var a Attempt
+		if err := json.Unmarshal(scanner.Bytes(), &a); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		h.Attempts = append(h.Attempts, a)
+	}
+	return h, scanner.Err()
+}
+
+// Record appends an attempt to the history and its file.
+func (m *AnswerHistory) Record(a Attempt) error {
+	b, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.Path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(m.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	m.Attempts = append(m.Attempts, a)
+	return nil
+}
+
+// For returns the attempts at a year, day and part, oldest first.
+func (m *AnswerHistory) For(year, day, part int) []Attempt {
+	var attempts []Attempt
+	for _, a := range m.Attempts {
+		if a.Year == year && a.Day == day && a.Part == part {
+			attempts = append(attempts, a)
+		}
+	}
+	return attempts
+}
+
+// Bounds returns the highest answer known to be too low and the lowest known
+// to be too high, either of which is empty if there is none.
+func (m *AnswerHistory) Bounds(year, day, part int) (low, high Answer) {
+	for _, a := range m.For(year, day, part) {
+		if !a.Answer.IsInteger() {
+			continue
+		}
+		switch {
+		case a.Verdict == VerdictTooLow && (low.IsEmpty() || a.Answer.BigInt().Cmp(low.BigInt()) > 0):
+			low = a.Answer
+		case a.Verdict == VerdictTooHigh && (high.IsEmpty() || a.Answer.BigInt().Cmp(high.BigInt()) < 0):
+			high = a.Answer
+		}
+	}
+	return low, high
+}
+
+// Check returns an error explaining why submitting answer at now would be
+// wasted: the part is already solved, the answer was already rejected, it is
+// outside the too low/too high bounds, or the site's wait has not passed.
+func (m *AnswerHistory) Check(year, day, part int, answer Answer, now time.Time) error {
+	attempts := m.For(year, day, part)
+	for _, a := range attempts {
+		switch {
+		case a.Verdict == VerdictCorrect:
+			return fmt.Errorf("day %d part %d was already solved with %s", day, part, a.Answer)
+		case a.Verdict.IsWrong() && a.Answer.Equal(answer):
+			return fmt.Errorf("%s was already submitted for day %d part %d and was %s", answer, day, part, a.Verdict)
+		}
+	}
+	if low, high := m.Bounds(year, day, part); answer.IsInteger() {
+		if !low.IsEmpty() && answer.BigInt().Cmp(low.BigInt()) <= 0 {
+			return fmt.Errorf("%s is too low: %s already was", answer, low)
+		}
+		if !high.IsEmpty() && answer.BigInt().Cmp(high.BigInt()) >= 0 {
+			return fmt.Errorf("%s is too high: %s already was", answer, high)
+		}
+	}
+	if len(attempts) > 0 {
+		last := attempts[len(attempts)-1]
+		if left := last.Time.Add(last.Wait).Sub(now); left > 0 {
+			return fmt.Errorf("the site asked us to wait; try again in %s", left.Round(time.Second))
+		}
+	}
+	return nil
+}
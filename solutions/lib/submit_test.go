@@ -0,0 +1,118 @@
+package eulerlib
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Response pages in the shape the site returns for a submitted answer.
+const (
+	correctPage  = `<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to decorating the North Pole. <a href="/2025/day/1#part2">[Continue to Part Two]</a></p></article></main>`
+	tooHighPage  = `<main><article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. <a href="/2025/day/1">[Return to Day 1]</a></p></article></main>`
+	tooLowPage   = `<main><article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article></main>`
+	wrongPage    = `<main><article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2025/about">about page</a>.</p></article></main>`
+	waitPage     = `<main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 12s left to wait. <a href="/2025/day/1">[Return to Day 1]</a></p></article></main>`
+	waitSecsPage = `<main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 39s left to wait.</p></article></main>`
+	solvedPage   = `<main><article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2025/day/1">[Return to Day 1]</a></p></article></main>`
+)
+
+func TestParseSubmitResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		{"correct", correctPage, VerdictCorrect, 0},
+		{"too high", tooHighPage, VerdictTooHigh, time.Minute},
+		{"too low", tooLowPage, VerdictTooLow, 5 * time.Minute},
+		{"wrong", wrongPage, VerdictWrong, 0},
+		{"wait", waitPage, VerdictWait, 4*time.Minute + 12*time.Second},
+		{"wait seconds", waitSecsPage, VerdictWait, 39 * time.Second},
+		{"solved", solvedPage, VerdictSolved, 0},
+		{"unrecognised", "<html>maintenance</html>", VerdictUnknown, 0},
+	}
+	for _, test := range tests {
+		result := ParseSubmitResponse(test.page)
+		CheckTest(t, "submit.ParseSubmitResponse", TTest{Name: test.name + " verdict", Expect: test.verdict}, result.Verdict)
+		CheckTest(t, "submit.ParseSubmitResponse", TTest{Name: test.name + " wait", Expect: test.wait}, result.Wait)
+	}
+	result := ParseSubmitResponse(correctPage)
+	CheckTest(t, "submit.ParseSubmitResponse", TTest{Name: "message", Expect: "That's the right answer! You are one gold star closer to decorating the North Pole. [Continue to Part Two]"}, result.Message)
+}
+
+func TestSubmit(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = map[string]string{"path": r.URL.Path, "method": r.Method, "level": r.PostForm.Get("level"), "answer": r.PostForm.Get("answer")}
+		w.Write([]byte(tooLowPage))
+	}))
+	defer server.Close()
+	f := NewFetcher("secret")
+	f.BaseURL = server.URL
+	f.Interval = 0
+
+	result, err := f.Submit(context.Background(), 2025, 3, 2, IntAnswer(1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "submit.Submit", TTest{Name: "verdict", Expect: VerdictTooLow}, result.Verdict)
+	CheckTest(t, "submit.Submit", TTest{Name: "form", Expect: map[string]string{"path": "/2025/day/3/answer", "method": "POST", "level": "2", "answer": "1234"}}, form)
+	if _, err := f.Submit(context.Background(), 2025, 3, 2, Answer{}); err == nil {
+		t.Error("expected an error submitting an empty answer")
+	}
+}
+
+func TestAnswerHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), HistoryFile)
+	h, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, 12, 1, 5, 0, 0, 0, time.UTC)
+	for _, a := range []Attempt{
+		{Time: start, Year: 2025, Day: 1, Part: 1, Answer: IntAnswer(500), Verdict: VerdictTooLow, Wait: time.Minute},
+		{Time: start.Add(2 * time.Minute), Year: 2025, Day: 1, Part: 1, Answer: IntAnswer(2000), Verdict: VerdictTooHigh, Wait: time.Minute},
+		{Time: start.Add(4 * time.Minute), Year: 2025, Day: 1, Part: 1, Answer: IntAnswer(1500), Verdict: VerdictWrong, Wait: 5 * time.Minute},
+		{Time: start, Year: 2025, Day: 2, Part: 1, Answer: StringAnswer("abc"), Verdict: VerdictCorrect},
+	} {
+		if err := h.Record(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h, err = LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "submit.LoadHistory", TTest{Name: "attempts", Expect: 4}, len(h.Attempts))
+	CheckTest(t, "submit.LoadHistory", TTest{Name: "answer", Expect: "2000"}, h.Attempts[1].Answer.String())
+	low, high := h.Bounds(2025, 1, 1)
+	CheckTest(t, "submit.Bounds", TTest{Name: "bounds", Expect: []string{"500", "2000"}}, []string{low.String(), high.String()})
+
+	later := start.Add(time.Hour)
+	tests := []struct {
+		name   string
+		day    int
+		answer Answer
+		now    time.Time
+		ok     bool
+	}{
+		{"inside bounds", 1, IntAnswer(1000), later, true},
+		{"already wrong", 1, IntAnswer(1500), later, false},
+		{"below too low", 1, IntAnswer(500), later, false},
+		{"above too high", 1, IntAnswer(3000), later, false},
+		{"still waiting", 1, IntAnswer(1000), start.Add(6 * time.Minute), false},
+		{"already solved", 2, StringAnswer("xyz"), later, false},
+		{"no history", 3, IntAnswer(1), start, true},
+	}
+	for _, test := range tests {
+		err := h.Check(2025, test.day, 1, test.answer, test.now)
+		CheckTest(t, "submit.Check", TTest{Name: test.name, Expect: test.ok}, err == nil)
+	}
+}
@@ -0,0 +1,318 @@
+package eulerlib
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Grid is a rectangular grid of T stored row by row in one slice, a typed
+// alternative to TGrid. Cell (x, y) is Cells[y*Width+x].
+type Grid[T comparable] struct {
+	Width  int
+	Height int
+	Cells  []T
+}
+
+// NewGrid returns a width by height grid of zero values.
+func NewGrid[T comparable](width, height int) *Grid[T] {
+	return &Grid[T]{Width: width, Height: height, Cells: make([]T, width*height)}
+}
+
+// NewGridFilled returns a width by height grid with every cell set to v.
+func NewGridFilled[T comparable](width, height int, v T) *Grid[T] {
+	g := NewGrid[T](width, height)
+	g.Fill(v)
+	return g
+}
+
+// ParseGrid builds a grid from lines, one cell per rune, converting each
+// rune with cell. The lines must all be the same length; a ragged line or a
+// rune that cell rejects is reported as a ParseError.
+func ParseGrid[T comparable](lines []string, cell func(r rune) (T, error)) (*Grid[T], error) {
+	if len(lines) == 0 {
+		return &Grid[T]{}, nil
+	}
+	width := utf8.RuneCountInString(lines[0])
+	g := &Grid[T]{Width: width, Height: len(lines), Cells: make([]T, 0, width*len(lines))}
+	for y, line := range lines {
+		if n := utf8.RuneCountInString(line); n != width {
+			return nil, &ParseError{Line: y + 1, Text: line, Msg: fmt.Sprintf("expected a row of %d characters, got %d", width, n)}
+		}
+		col := 0
+		for _, r := range line {
+			col++
+			v, err := cell(r)
+			if err != nil {
+				return nil, &ParseError{Line: y + 1, Col: col, Text: line, Msg: err.Error()}
+			}
+			g.Cells = append(g.Cells, v)
+		}
+	}
+	return g, nil
+}
+
+// ParseRuneGrid builds a grid of the runes in lines.
+func ParseRuneGrid(lines []string) (*Grid[rune], error) {
+	return ParseGrid(lines, func(r rune) (rune, error) { return r, nil })
+}
+
+// ParseByteGrid builds a grid of the bytes in lines, for ASCII input where a
+// byte per cell is enough.
+func ParseByteGrid(lines []string) (*Grid[byte], error) {
+	if len(lines) == 0 {
+		return &Grid[byte]{}, nil
+	}
+	width := len(lines[0])
+	g := &Grid[byte]{Width: width, Height: len(lines), Cells: make([]byte, 0, width*len(lines))}
+	for y, line := range lines {
+		if len(line) != width {
+			return nil, &ParseError{Line: y + 1, Text: line, Msg: fmt.Sprintf("expected a row of %d characters, got %d", width, len(line))}
+		}
+		g.Cells = append(g.Cells, line...)
+	}
+	return g, nil
+}
+
+// ParseDigitGrid builds a grid of ints from lines of decimal digits, one
+// digit per cell.
+func ParseDigitGrid(lines []string) (*Grid[int], error) {
+	return ParseGrid(lines, func(r rune) (int, error) {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("expected a digit, got %q", r)
+		}
+		return RuneToInt(r), nil
+	})
+}
+
+// MustParseRuneGrid is like ParseRuneGrid but panics if the lines are
+// ragged.
+func MustParseRuneGrid(lines []string) *Grid[rune] {
+	return must(ParseRuneGrid(lines))
+}
+
+// MustParseByteGrid is like ParseByteGrid but panics if the lines are
+// ragged.
+func MustParseByteGrid(lines []string) *Grid[byte] {
+	return must(ParseByteGrid(lines))
+}
+
+// MustParseDigitGrid is like ParseDigitGrid but panics if the lines are
+// ragged or hold anything but digits.
+func MustParseDigitGrid(lines []string) *Grid[int] {
+	return must(ParseDigitGrid(lines))
+}
+
+// In reports whether (x, y) lies inside the grid.
+func (m *Grid[T]) In(x, y int) bool {
+	return x >= 0 && y >= 0 && x < m.Width && y < m.Height
+}
+
+// Get returns the value at (x, y), or the zero value and false if (x, y) is
+// outside the grid.
+func (m *Grid[T]) Get(x, y int) (T, bool) {
+	if !m.In(x, y) {
+		var zero T
+		return zero, false
+	}
+	return m.Cells[y*m.Width+x], true
+}
+
+// Set stores v at (x, y), reporting false and leaving the grid unchanged if
+// (x, y) is outside it.
+func (m *Grid[T]) Set(x, y int, v T) bool {
+	if !m.In(x, y) {
+		return false
+	}
+	m.Cells[y*m.Width+x] = v
+	return true
+}
+
+// At returns the value at (x, y) without a bounds check, for hot loops that
+// already know (x, y) is inside the grid. An x outside the grid reads a
+// cell of the neighbouring row.
+func (m *Grid[T]) At(x, y int) T {
+	return m.Cells[y*m.Width+x]
+}
+
+// Put stores v at (x, y) without a bounds check, as At reads.
+func (m *Grid[T]) Put(x, y int, v T) {
+	m.Cells[y*m.Width+x] = v
+}
+
+// Contains reports whether p lies inside the grid, so that the grid can
+// limit a point's neighbours to its Bounds.
+func (m *Grid[T]) Contains(p Point) bool {
+	return m.In(p.X, p.Y)
+}
+
+// GetAt is Get for a Point.
+func (m *Grid[T]) GetAt(p Point) (T, bool) {
+	return m.Get(p.X, p.Y)
+}
+
+// SetAt is Set for a Point.
+func (m *Grid[T]) SetAt(p Point, v T) bool {
+	return m.Set(p.X, p.Y, v)
+}
+
+// Size returns the width and height of the grid.
+func (m *Grid[T]) Size() GridSize {
+	return GridSize{Width: m.Width, Height: m.Height}
+}
+
+// Fill sets every cell to v.
+func (m *Grid[T]) Fill(v T) {
+	for i := range m.Cells {
+		m.Cells[i] = v
+	}
+}
+
+// Row returns row y, sharing the grid's storage so that writes to it change
+// the grid.
+func (m *Grid[T]) Row(y int) []T {
+	return m.Cells[y*m.Width : (y+1)*m.Width : (y+1)*m.Width]
+}
+
+// Column returns a copy of column x.
+func (m *Grid[T]) Column(x int) []T {
+	col := make([]T, m.Height)
+	for y := range col {
+		col[y] = m.Cells[y*m.Width+x]
+	}
+	return col
+}
+
+// All iterates over every cell in reading order, yielding its position and
+// value.
+func (m *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range m.Cells {
+			if !yield(m.pointOf(i), v) {
+				return
+			}
+		}
+	}
+}
+
+// Rows iterates over the rows from top to bottom, as Row returns them.
+func (m *Grid[T]) Rows() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for y := 0; y < m.Height; y++ {
+			if !yield(y, m.Row(y)) {
+				return
+			}
+		}
+	}
+}
+
+// Columns iterates over copies of the columns from left to right.
+func (m *Grid[T]) Columns() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for x := 0; x < m.Width; x++ {
+			if !yield(x, m.Column(x)) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the grid that shares no storage with it.
+func (m *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{Width: m.Width, Height: m.Height, Cells: slices.Clone(m.Cells)}
+}
+
+// Equal reports whether other has the same size and cells as the grid.
+func (m *Grid[T]) Equal(other *Grid[T]) bool {
+	return m.Width == other.Width && m.Height == other.Height && slices.Equal(m.Cells, other.Cells)
+}
+
+// pointOf returns the position of Cells[i].
+func (m *Grid[T]) pointOf(i int) Point {
+	return Point{i % m.Width, i / m.Width}
+}
+
+// Find returns the position of the first cell, in reading order, equal to v,
+// or false if there is none. It is the typed FindElement.
+func (m *Grid[T]) Find(v T) (Point, bool) {
+	i := slices.Index(m.Cells, v)
+	if i < 0 {
+		return Point{-1, -1}, false
+	}
+	return m.pointOf(i), true
+}
+
+// FindAll returns the position of every cell equal to v, in reading order.
+func (m *Grid[T]) FindAll(v T) []Point {
+	var found []Point
+	for i, c := range m.Cells {
+		if c == v {
+			found = append(found, m.pointOf(i))
+		}
+	}
+	return found
+}
+
+// Count counts the cells equal to v. It is the typed CountValues.
+func (m *Grid[T]) Count(v T) int {
+	return m.CountFunc(func(c T) bool { return c == v })
+}
+
+// CountFunc counts the cells for which f returns true.
+func (m *Grid[T]) CountFunc(f func(T) bool) int {
+	count := 0
+	for _, c := range m.Cells {
+		if f(c) {
+			count++
+		}
+	}
+	return count
+}
+
+// AdjacentCount counts how many of the (up to) 8 cells around p are equal to
+// v. It is the typed GetAdjacentCount.
+func (m *Grid[T]) AdjacentCount(p Point, v T) int {
+	count := 0
+	for n := range p.Neighbours8In(m) {
+		if m.At(n.X, n.Y) == v {
+			count++
+		}
+	}
+	return count
+}
+
+// Adjacent returns the positions of the (up to) 8 cells around p that are
+// equal to v, clockwise from the cell above. It is the typed
+// GetAdjacentList.
+func (m *Grid[T]) Adjacent(p Point, v T) []Point {
+	var found []Point
+	for n := range p.Neighbours8In(m) {
+		if m.At(n.X, n.Y) == v {
+			found = append(found, n)
+		}
+	}
+	return found
+}
+
+// String renders the grid one row per line. Runes and bytes are written as
+// characters and other values with fmt, so a rune or byte grid prints as it
+// was parsed.
+func (m *Grid[T]) String() string {
+	var sb strings.Builder
+	for _, row := range m.Rows() {
+		for _, v := range row {
+			switch c := any(v).(type) {
+			case rune:
+				sb.WriteRune(c)
+			case byte:
+				sb.WriteByte(c)
+			default:
+				fmt.Fprint(&sb, c)
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,133 @@
+package eulerlib
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+// comparePoints orders points in reading order.
+func comparePoints(a, b Point) int {
+	return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+}
+
+var testGridLines = []string{
+	"..@@.",
+	"@S@.@",
+	".@@@.",
+}
+
+func TestParseRuneGrid(t *testing.T) {
+	g, err := ParseRuneGrid(testGridLines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CheckTest(t, "Grid.Width", TTest{Name: "width", Expect: 5}, g.Width)
+	CheckTest(t, "Grid.Height", TTest{Name: "height", Expect: 3}, g.Height)
+	CheckTest(t, "Grid.String", TTest{Name: "round trip", Expect: "..@@.\n@S@.@\n.@@@.\n"}, g.String())
+
+	_, err = ParseRuneGrid([]string{"..", "...", ".."})
+	CheckTest(t, "ParseRuneGrid", TTest{Name: "ragged", Expect: `line 2: expected a row of 2 characters, got 3 in "..."`}, err.Error())
+	_, err = ParseByteGrid([]string{"..", "."})
+	CheckTest(t, "ParseByteGrid", TTest{Name: "ragged", Expect: `line 2: expected a row of 2 characters, got 1 in "."`}, err.Error())
+
+	b := MustParseByteGrid(testGridLines)
+	p, ok := b.Find('S')
+	CheckTest(t, "Grid.Find", TTest{Name: "byte", Expect: []any{Point{1, 1}, true}}, []any{p, ok})
+}
+
+func TestParseDigitGrid(t *testing.T) {
+	g := MustParseDigitGrid([]string{"123", "456"})
+	CheckTest(t, "ParseDigitGrid", TTest{Name: "cells", Expect: []int{1, 2, 3, 4, 5, 6}}, g.Cells)
+	CheckTest(t, "Grid.String", TTest{Name: "digits", Expect: "123\n456\n"}, g.String())
+
+	_, err := ParseDigitGrid([]string{"12", "3x"})
+	CheckTest(t, "ParseDigitGrid", TTest{Name: "not a digit", Expect: `line 2, col 2: expected a digit, got 'x' in "3x"`}, err.Error())
+}
+
+func TestGridAccess(t *testing.T) {
+	g := NewGridFilled(3, 2, '.')
+	CheckTest(t, "Grid.Set", TTest{Name: "inside", Expect: true}, g.Set(2, 1, '#'))
+	CheckTest(t, "Grid.Set", TTest{Name: "outside", Expect: false}, g.Set(3, 0, '#'))
+	v, ok := g.Get(2, 1)
+	CheckTest(t, "Grid.Get", TTest{Name: "inside", Expect: []any{'#', true}}, []any{v, ok})
+	v, ok = g.Get(-1, 0)
+	CheckTest(t, "Grid.Get", TTest{Name: "outside", Expect: []any{rune(0), false}}, []any{v, ok})
+
+	g.Put(0, 0, '@')
+	CheckTest(t, "Grid.At", TTest{Name: "put", Expect: '@'}, g.At(0, 0))
+	g.Row(1)[0] = '*'
+	CheckTest(t, "Grid.Row", TTest{Name: "shared", Expect: "@..\n*.#\n"}, g.String())
+	CheckTest(t, "Grid.Column", TTest{Name: "copy", Expect: []rune{'.', '#'}}, g.Column(2))
+}
+
+func TestGridIteration(t *testing.T) {
+	g := MustParseDigitGrid([]string{"12", "34", "56"})
+	sum := 0
+	var last Point
+	for p, v := range g.All() {
+		sum += v
+		last = p
+	}
+	CheckTest(t, "Grid.All", TTest{Name: "sum", Expect: 21}, sum)
+	CheckTest(t, "Grid.All", TTest{Name: "last", Expect: Point{1, 2}}, last)
+
+	var rows [][]int
+	for _, row := range g.Rows() {
+		rows = append(rows, row)
+	}
+	CheckTest(t, "Grid.Rows", TTest{Name: "rows", Expect: [][]int{{1, 2}, {3, 4}, {5, 6}}}, rows)
+	var cols [][]int
+	for _, col := range g.Columns() {
+		cols = append(cols, col)
+	}
+	CheckTest(t, "Grid.Columns", TTest{Name: "columns", Expect: [][]int{{1, 3, 5}, {2, 4, 6}}}, cols)
+
+	seen := 0
+	for range g.All() {
+		if seen++; seen == 3 {
+			break
+		}
+	}
+	CheckTest(t, "Grid.All", TTest{Name: "break", Expect: 3}, seen)
+}
+
+func TestGridCloneEqual(t *testing.T) {
+	g := MustParseRuneGrid(testGridLines)
+	c := g.Clone()
+	CheckTest(t, "Grid.Equal", TTest{Name: "clone", Expect: true}, g.Equal(c))
+	c.Put(0, 0, '#')
+	CheckTest(t, "Grid.Equal", TTest{Name: "changed", Expect: false}, g.Equal(c))
+	CheckTest(t, "Grid.Clone", TTest{Name: "independent", Expect: '.'}, g.At(0, 0))
+	CheckTest(t, "Grid.Equal", TTest{Name: "size", Expect: false}, NewGrid[int](2, 3).Equal(NewGrid[int](3, 2)))
+}
+
+func TestGridSearch(t *testing.T) {
+	g := MustParseRuneGrid(testGridLines)
+	CheckTest(t, "Grid.Count", TTest{Name: "rolls", Expect: 8}, g.Count('@'))
+	CheckTest(t, "Grid.CountFunc", TTest{Name: "not empty", Expect: 9}, g.CountFunc(func(r rune) bool { return r != '.' }))
+	p, ok := g.Find('#')
+	CheckTest(t, "Grid.Find", TTest{Name: "missing", Expect: []any{Point{-1, -1}, false}}, []any{p, ok})
+	CheckTest(t, "Grid.FindAll", TTest{Name: "top row", Expect: []Point{{2, 0}, {3, 0}}}, g.FindAll('@')[:2])
+	CheckTest(t, "Grid.Adjacent", TTest{Name: "clockwise", Expect: []Point{{2, 0}, {2, 1}, {2, 2}, {1, 2}, {0, 1}}}, g.Adjacent(Point{1, 1}, '@'))
+
+	//the typed methods agree with TGrid's
+	tg := &TGrid{}
+	tg.Init()
+	tg.ParseTable(testGridLines, false)
+	for p := range g.All() {
+		if got, want := g.AdjacentCount(p, '@'), tg.GetAdjacentCount(p.X, p.Y, '@'); got != want {
+			t.Errorf("AdjacentCount(%v) = %d, want %d", p, got, want)
+		}
+		got, want := g.Adjacent(p, '@'), tg.Adjacent(p, '@')
+		slices.SortFunc(got, comparePoints)
+		slices.SortFunc(want, comparePoints)
+		if !slices.Equal(got, want) {
+			t.Errorf("Adjacent(%v) = %v, want %v", p, got, want)
+		}
+	}
+	tp, _ := tg.Find('S')
+	p, _ = g.Find('S')
+	CheckTest(t, "Grid.Find", TTest{Name: "as FindElement", Expect: tp}, p)
+	CheckTest(t, "Grid.Count", TTest{Name: "as CountValues", Expect: tg.CountValues('@')}, g.Count('@'))
+}
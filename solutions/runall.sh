@@ -1 +1 @@
-for d in ./day*/ ; do (echo "$d" && cd "$d" && go run .); done
+go run ./cmd/aoc run -all -j 0 "$@"
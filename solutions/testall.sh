@@ -1 +1 @@
-for d in ./*/ ; do (echo "$d" && cd "$d" && go test); done
+for d in ./day*/ ./lib/ ./cmd/*/ ; do (echo "$d" && cd "$d" && go test); done